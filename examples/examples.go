@@ -3,7 +3,7 @@ package lists_examples
 import (
 	"fmt"
 
-	"github.com/tanerius/lists/lists"
+	"github.com/tanerius/lists/v2/lists"
 )
 
 func Examples() {
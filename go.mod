@@ -1,3 +1,3 @@
-module github.com/tanerius/lists
+module github.com/tanerius/lists/v2
 
-go 1.22.2
+go 1.23.0
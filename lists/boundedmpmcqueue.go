@@ -0,0 +1,282 @@
+package lists
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"sync/atomic"
+)
+
+// Struct holding a slot of a BoundedMPMCQueue. seq tells whose turn it is: the producer of position p may
+// fill the slot while seq is p, and the consumer of position p may empty it once seq is p+1
+type mpmcCell[T any] struct {
+	seq   atomic.Uint64
+	value atomic.Pointer[T]
+}
+
+// The BoundedMPMCQueue is a lock-free queue of limited size for any number of producer and consumer
+// goroutines, the bounded queue of Dmitry Vyukov. Producers and consumers claim positions with a single
+// compare-and-swap and then hand the slot over through its sequence number, so they only contend when
+// they go for the same position. The ring size is a power of two. Elements are boxed, which lets Peek and
+// the iterators read elements other goroutines may be dequeuing at the same time, at the cost of one
+// allocation per enqueued element.
+//
+// BoundedMPMCQueue is thread safe. However only the queue structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// BoundedMPMCQueue is a list that implements the Fifo interface. Enqueue waits for a consumer while the
+// queue is full by spinning and yielding the processor, which burns CPU for as long as the queue stays full.
+// TryEnqueue fails instead, and suits producers which can back off or wait on something else.
+type BoundedMPMCQueue[T any] struct {
+	cells []mpmcCell[T]
+	mask  uint64
+
+	_          cacheLinePad
+	enqueuePos atomic.Uint64 // the next position a producer claims
+	_          cacheLinePad
+	dequeuePos atomic.Uint64 // the next position a consumer claims
+	_          cacheLinePad
+}
+
+// The constructor for a new BoundedMPMCQueue instance with elements of type T which holds at least size
+// elements. The size is rounded up to a power of two, and a size below 2 is treated as 2. Panics with an
+// error wrapping ErrOutOfRange if size is above 1<<63, which can't be rounded up.
+//
+// Returns a pointer to a BoundedMPMCQueue
+func NewBoundedMPMCQueue[T any](size uint) *BoundedMPMCQueue[T] {
+	n := ringSize("BoundedMPMCQueue", "NewBoundedMPMCQueue", size, 2)
+
+	r := &BoundedMPMCQueue[T]{
+		cells: make([]mpmcCell[T], n),
+		mask:  n - 1,
+	}
+	for i := range r.cells {
+		r.cells[i].seq.Store(uint64(i))
+	}
+	return r
+}
+
+// Return the number of elements the queue can hold
+func (r *BoundedMPMCQueue[T]) Capacity() int {
+	return len(r.cells)
+}
+
+// Return the number of elements in the queue, counting those being enqueued or dequeued. While other
+// goroutines are active the count may be outdated as soon as it is returned
+func (r *BoundedMPMCQueue[T]) Count() uint {
+	dequeuePos := r.dequeuePos.Load()
+	enqueuePos := r.enqueuePos.Load()
+	if enqueuePos < dequeuePos {
+		return 0
+	}
+	return uint(min(enqueuePos-dequeuePos, uint64(len(r.cells))))
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *BoundedMPMCQueue[T]) IsEmpty() bool {
+	return r.Count() == 0
+}
+
+// Checks if the queue is full
+//
+// Return true if the queue holds Capacity elements. Otherwise returns false.
+func (r *BoundedMPMCQueue[T]) IsFull() bool {
+	return r.Count() == uint(len(r.cells))
+}
+
+// Add an element of type T to the end of the queue, spinning until a consumer makes room while the queue
+// is full. Complexity is O(1)
+func (r *BoundedMPMCQueue[T]) Enqueue(element T) {
+	for !r.offer(&element) {
+		runtime.Gosched()
+	}
+}
+
+// Add an element of type T to the end of the queue. Complexity is O(1)
+//
+// Returns an error if the queue is full
+func (r *BoundedMPMCQueue[T]) TryEnqueue(element T) error {
+	if !r.offer(&element) {
+		return newContainerError("BoundedMPMCQueue", "TryEnqueue", ErrFull)
+	}
+	return nil
+}
+
+// A hidden method which stores the boxed element if there is room for it
+//
+// Returns false if the queue is full
+func (r *BoundedMPMCQueue[T]) offer(box *T) bool {
+	pos := r.enqueuePos.Load()
+	for {
+		cell := &r.cells[pos&r.mask]
+		seq := cell.seq.Load()
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			if r.enqueuePos.CompareAndSwap(pos, pos+1) {
+				cell.value.Store(box)
+				cell.seq.Store(pos + 1) // hands the slot to the consumer of pos
+				return true
+			}
+			pos = r.enqueuePos.Load()
+		case diff < 0:
+			// the slot still holds the element of the previous round
+			return false
+		default:
+			// another producer claimed pos
+			pos = r.enqueuePos.Load()
+		}
+	}
+}
+
+// Add elements of type T to the end of the queue in the given order, waiting for consumers while the
+// queue is full. Elements of other producers may end up in between. Complexity is O(k)
+func (r *BoundedMPMCQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order, waiting for consumers while the queue
+// is full. Elements of other producers may end up in between. Complexity is O(k)
+func (r *BoundedMPMCQueue[T]) EnqueueSlice(elements []T) {
+	for _, element := range elements {
+		r.Enqueue(element)
+	}
+}
+
+// Remove and return an element of type T from the beginning of the queue. Complexity is O(1)
+func (r *BoundedMPMCQueue[T]) Dequeue() (T, error) {
+	pos := r.dequeuePos.Load()
+	for {
+		cell := &r.cells[pos&r.mask]
+		seq := cell.seq.Load()
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			if r.dequeuePos.CompareAndSwap(pos, pos+1) {
+				result := *cell.value.Swap(nil)
+				cell.seq.Store(pos + r.mask + 1) // hands the slot to the producer of the next round
+				return result, nil
+			}
+			pos = r.dequeuePos.Load()
+		case diff < 0:
+			// the producer of pos hasn't filled the slot yet
+			var result T
+			return result, newContainerError("BoundedMPMCQueue", "Dequeue", ErrEmpty)
+		default:
+			// another consumer claimed pos
+			pos = r.dequeuePos.Load()
+		}
+	}
+}
+
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// Elements of other consumers may be taken in between. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *BoundedMPMCQueue[T]) DequeueInto(dst []T) int {
+	for i := range dst {
+		element, err := r.Dequeue()
+		if err != nil {
+			return i
+		}
+		dst[i] = element
+	}
+	return len(dst)
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order. Complexity is O(n)
+func (r *BoundedMPMCQueue[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.Count())))
+	return s[:r.DequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue
+func (r *BoundedMPMCQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// A hidden method which returns the box of the element at pos, or nil if the slot doesn't hold it
+func (r *BoundedMPMCQueue[T]) at(pos uint64) *T {
+	cell := &r.cells[pos&r.mask]
+	if cell.seq.Load() != pos+1 {
+		return nil
+	}
+	p := cell.value.Load()
+	if cell.seq.Load() != pos+1 {
+		// the slot moved on to another round while reading it
+		return nil
+	}
+	return p
+}
+
+// Return an element of type T from the beginning of the queue without Dequeuing it. Complexity is O(1)
+func (r *BoundedMPMCQueue[T]) Peek() (T, error) {
+	for {
+		pos := r.dequeuePos.Load()
+		if p := r.at(pos); p != nil {
+			return *p, nil
+		}
+		if r.dequeuePos.Load() == pos {
+			// nothing was dequeued meanwhile, so the slot is empty
+			var result T
+			return result, newContainerError("BoundedMPMCQueue", "Peek", ErrEmpty)
+		}
+	}
+}
+
+// Return a slice representation of the queue in Dequeue order. Complexity is O(n)
+func (r *BoundedMPMCQueue[T]) ToSlice() []T {
+	s := make([]T, 0, r.Count())
+	for element := range r.All() {
+		s = append(s, element)
+	}
+	return s
+}
+
+// Return an iterator over the elements of the queue from front to back, in Dequeue order.
+// The iterator reads the slots while other goroutines use the queue: elements dequeued meanwhile are
+// skipped and elements enqueued meanwhile may be included
+func (r *BoundedMPMCQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		end := r.enqueuePos.Load()
+		for pos := r.dequeuePos.Load(); pos < end; pos++ {
+			if p := r.at(pos); p != nil && !yield(*p) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front. The elements are collected
+// first. Complexity is O(n)
+func (r *BoundedMPMCQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := r.ToSlice()
+		for _, element := range slices.Backward(s) {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back
+func (r *BoundedMPMCQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for element := range r.All() {
+			if !yield(i, element) {
+				return
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package lists
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// The BoundedQueue is a collection of entities that are maintained in a sequence and can be modified
+// by the addition of entities at one end of the sequence and the removal of entities from the
+// other end of the sequence. Unlike LSQueue it never discards elements: once the queue is full, producers
+// wait for consumers to make room, which gives a pipeline natural backpressure.
+//
+// BoundedQueue is thread safe. However only the queue structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// BoundedQueue is a list that implements the BlockingFifo interface
+type BoundedQueue[T any] struct {
+	maxBuffSize uint
+	curBuffSize uint
+	headIndex   uint
+	data        []T
+	mu          sync.RWMutex
+	notEmpty    *sync.Cond // signalled whenever an element is enqueued
+	notFull     *sync.Cond // signalled whenever an element is dequeued
+}
+
+// The constructor for a new BoundedQueue instance with elements of type T which holds at most size elements.
+// A size of 0 is treated as 1.
+//
+// Returns a pointer to a BoundedQueue
+func NewBoundedQueue[T any](size uint) *BoundedQueue[T] {
+	if size == 0 {
+		size = 1
+	}
+
+	r := &BoundedQueue[T]{
+		maxBuffSize: size,
+		curBuffSize: 0,
+		headIndex:   0,
+		data:        make([]T, size),
+	}
+	r.notEmpty = sync.NewCond(&r.mu)
+	r.notFull = sync.NewCond(&r.mu)
+	return r
+}
+
+// A hidden method which reports whether the queue holds elements. Must be called with the lock held
+func (r *BoundedQueue[T]) hasElements() bool {
+	return r.curBuffSize > 0
+}
+
+// A hidden method which reports whether the queue has room for an element. Must be called with the lock held
+func (r *BoundedQueue[T]) hasRoom() bool {
+	return r.curBuffSize < r.maxBuffSize
+}
+
+// A hidden method which enqueues an element. Must be called with the lock held and room available
+func (r *BoundedQueue[T]) enqueue(element T) {
+	r.data[(r.headIndex+r.curBuffSize)%r.maxBuffSize] = element
+	r.curBuffSize++
+	r.notEmpty.Broadcast()
+}
+
+// A hidden method which dequeues an element. Must be called with the lock held
+func (r *BoundedQueue[T]) dequeue() (T, error) {
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError("BoundedQueue", "Dequeue", ErrEmpty)
+	}
+
+	result = r.data[r.headIndex]
+	var zero T
+	r.data[r.headIndex] = zero
+	r.headIndex = (r.headIndex + 1) % r.maxBuffSize
+	r.curBuffSize--
+	r.notFull.Broadcast()
+
+	return result, nil
+}
+
+// Return the maximum number of elements the queue can hold
+func (r *BoundedQueue[T]) Capacity() int {
+	return int(r.maxBuffSize)
+}
+
+// Add an element of type T to the end of the queue, blocking while the queue is full. Complexity is O(1)
+func (r *BoundedQueue[T]) Enqueue(element T) {
+	r.EnqueueCtx(context.Background(), element)
+}
+
+// Add an element of type T to the end of the queue, blocking while the queue is full or until ctx is done.
+// Complexity is O(1)
+//
+// Returns an error wrapping the context error if the element could not be added before ctx was done
+func (r *BoundedQueue[T]) EnqueueCtx(ctx context.Context, element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := waitFor(ctx, "BoundedQueue", "EnqueueCtx", r.notFull, r.hasRoom); err != nil {
+		return err
+	}
+	r.enqueue(element)
+	return nil
+}
+
+// Add elements of type T to the end of the queue in the given order, blocking whenever the queue is full.
+// Complexity is O(k)
+func (r *BoundedQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order, blocking whenever the queue is full.
+// The lock is only released while waiting for room. Complexity is O(k)
+func (r *BoundedQueue[T]) EnqueueSlice(elements []T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, element := range elements {
+		for !r.hasRoom() {
+			r.notFull.Wait()
+		}
+		r.enqueue(element)
+	}
+}
+
+// Add an element of type T to the end of the queue if there is room for it. Never blocks. Complexity is O(1)
+//
+// Returns an error if the queue is full
+func (r *BoundedQueue[T]) TryEnqueue(element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.hasRoom() {
+		return newContainerError("BoundedQueue", "TryEnqueue", ErrFull)
+	}
+	r.enqueue(element)
+	return nil
+}
+
+// Remove and return am element of type T from the beginning of the queue. Complexity is O(1)
+func (r *BoundedQueue[T]) Dequeue() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.dequeue()
+}
+
+// Remove and return an element of type T from the beginning of the queue, blocking until one is available
+// or ctx is done. Complexity is O(1)
+func (r *BoundedQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := waitFor(ctx, "BoundedQueue", "DequeueWait", r.notEmpty, r.hasElements); err != nil {
+		var result T
+		return result, err
+	}
+	return r.dequeue()
+}
+
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// The lock is taken once for the whole batch. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *BoundedQueue[T]) DequeueInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.dequeueInto(dst)
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order.
+// The lock is taken once for the whole batch. Complexity is O(n)
+func (r *BoundedQueue[T]) DequeueN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.dequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue. Every element is dequeued under
+// its own lock so producers are not held up while the loop body runs; use DequeueN to take a batch at once.
+func (r *BoundedQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// A hidden method which dequeues up to len(dst) elements into dst. Must be called with the lock held
+func (r *BoundedQueue[T]) dequeueInto(dst []T) int {
+	n := min(len(dst), int(r.curBuffSize))
+
+	var zero T
+	for i := 0; i < n; i++ {
+		dst[i] = r.data[r.headIndex]
+		r.data[r.headIndex] = zero
+		r.headIndex = (r.headIndex + 1) % r.maxBuffSize
+	}
+
+	r.curBuffSize -= uint(n)
+	if n > 0 {
+		r.notFull.Broadcast()
+	}
+	return n
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *BoundedQueue[T]) IsEmpty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.curBuffSize == 0
+}
+
+// Checks if the queue is full. While it is, Enqueue blocks and TryEnqueue fails
+//
+// Return true if the queue has reached its capacity. Otherwise returns false.
+func (r *BoundedQueue[T]) IsFull() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.curBuffSize == r.maxBuffSize
+}
+
+// Return am element of type T from the beginning of the queue without Dequeuing it. Complexity is O(1)
+func (r *BoundedQueue[T]) Peek() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError("BoundedQueue", "Peek", ErrEmpty)
+	}
+
+	return r.data[r.headIndex], nil
+}
+
+// Return an element of type T from the beginning of the queue without Dequeuing it, blocking until one is
+// available or ctx is done. Complexity is O(1)
+func (r *BoundedQueue[T]) PeekWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := waitFor(ctx, "BoundedQueue", "PeekWait", r.notEmpty, r.hasElements); err != nil {
+		var result T
+		return result, err
+	}
+
+	return r.data[r.headIndex], nil
+}
+
+// Return the element of type T at position i counted from the front of the queue, where 0 is the element
+// Dequeue would return. Complexity is O(1)
+func (r *BoundedQueue[T]) At(i int) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if err := checkIndex("BoundedQueue", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	return r.data[(int(r.headIndex)+i)%int(r.maxBuffSize)], nil
+}
+
+// Replace the element at position i counted from the front of the queue. Complexity is O(1)
+func (r *BoundedQueue[T]) Set(i int, element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := checkIndex("BoundedQueue", "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	r.data[(int(r.headIndex)+i)%int(r.maxBuffSize)] = element
+	return nil
+}
+
+// Return the most recently enqueued element of type T without Dequeuing it. Complexity is O(1)
+func (r *BoundedQueue[T]) PeekBack() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError("BoundedQueue", "PeekBack", ErrEmpty)
+	}
+
+	return r.data[(int(r.headIndex)+int(r.curBuffSize)-1)%int(r.maxBuffSize)], nil
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// The read lock is held while searching, so f must not call methods of the queue. Complexity is O(n)
+func (r *BoundedQueue[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the queue satisfies f. Complexity is O(n)
+func (r *BoundedQueue[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the queue
+func (r *BoundedQueue[T]) ToSlice() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := make([]T, 0, r.curBuffSize)
+	walkRing(r.data, int(r.headIndex), int(r.curBuffSize), func(_ int, v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// Return an iterator over the elements of the queue from front to back, in Dequeue order.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *BoundedQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkRing(r.data, int(r.headIndex), int(r.curBuffSize), func(_ int, v T) bool { return yield(v) })
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *BoundedQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkRingBackward(r.data, int(r.headIndex), int(r.curBuffSize), yield)
+	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *BoundedQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkRing(r.data, int(r.headIndex), int(r.curBuffSize), yield)
+	}
+}
+
+// Return the number of elements in the queue
+func (r *BoundedQueue[T]) Count() uint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.curBuffSize
+}
+
+// Remove all elements from the queue and wake up producers waiting for room. The buffer is kept and cleared.
+// Complexity is O(n)
+func (r *BoundedQueue[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.data)
+	r.curBuffSize = 0
+	r.headIndex = 0
+	r.notFull.Broadcast()
+}
+
+// Return a copy of the queue with its own buffer. The elements themselves are copied by value.
+// The read lock is held while copying. Complexity is O(n)
+func (r *BoundedQueue[T]) Clone() *BoundedQueue[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := &BoundedQueue[T]{
+		maxBuffSize: r.maxBuffSize,
+		curBuffSize: r.curBuffSize,
+		headIndex:   r.headIndex,
+		data:        slices.Clone(r.data),
+	}
+	c.notEmpty = sync.NewCond(&c.mu)
+	c.notFull = sync.NewCond(&c.mu)
+	return c
+}
@@ -0,0 +1,101 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedQueue(t *testing.T) {
+	var _ BlockingFifo[int] = NewBoundedQueue[int](1)
+	queue := NewBoundedQueue[int](3)
+
+	//check Capacity method
+	if queue.Capacity() != 3 {
+		t.Errorf("Capacity() = %v, want %v", queue.Capacity(), 3)
+	}
+
+	// dequeue from an empty queue
+	_, err := queue.Dequeue()
+	if err == nil {
+		t.Errorf("Dequeue() = %v, want %v", err, "empty list")
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := queue.TryEnqueue(i); err != nil {
+			t.Errorf("TryEnqueue() error = %v, want %v", err, nil)
+		}
+	}
+
+	// test if full
+	if !queue.IsFull() {
+		t.Errorf("IsFull() = %v, want %v", queue.IsFull(), true)
+	}
+	if err := queue.TryEnqueue(3); !errors.Is(err, ErrFull) {
+		t.Errorf("TryEnqueue() on a full queue error = %v, want %v", err, ErrFull)
+	}
+
+	// enqueueing on a full queue stops with the context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.EnqueueCtx(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("EnqueueCtx() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// a blocked producer is released by a consumer
+	done := make(chan struct{})
+	go func() {
+		queue.Enqueue(3)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if element, _ := queue.Dequeue(); element != 0 {
+		t.Errorf("Dequeue() = %v, want %v", element, 0)
+	}
+	<-done
+
+	if s := queue.ToSlice(); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 2, 3})
+	}
+}
+
+func TestBoundedQueueProducerConsumer(t *testing.T) {
+	queue := NewBoundedQueue[int](4)
+	var wg sync.WaitGroup
+
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				queue.Enqueue(i)
+			}
+		}()
+	}
+
+	sum := 0
+	for i := 0; i < 4000; i++ {
+		element, err := queue.DequeueWait(context.Background())
+		if err != nil {
+			t.Fatalf("DequeueWait() = %v, want %v", err, nil)
+		}
+		sum += element
+	}
+	wg.Wait()
+
+	if sum != 4*999*1000/2 {
+		t.Errorf("sum = %v, want %v", sum, 4*999*1000/2)
+	}
+}
+
+func BenchmarkBoundedQueueEnqueueDequeue(b *testing.B) {
+	queue := NewBoundedQueue[int](1024)
+
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+		queue.Dequeue()
+	}
+}
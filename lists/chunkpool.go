@@ -0,0 +1,108 @@
+package lists
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Struct identifying a shared chunk pool. Chunks can only be shared between containers with the same
+// element type and chunk size
+type chunkPoolKey struct {
+	typ  reflect.Type
+	size uint
+}
+
+// The shared pools of chunks, mapping a chunkPoolKey to a *sync.Pool
+var sharedChunkPools sync.Map
+
+// Struct recycling the chunks a container no longer uses. It is owned by a single container and is
+// protected by that container's lock, if any
+type chunkPool[T any] struct {
+	size     uint
+	free     *arrnode[T] // spare chunks linked through next
+	spare    uint
+	maxSpare uint
+	shared   *sync.Pool
+}
+
+// Constructor for a chunk pool following the given settings
+func newChunkPool[T any](config chunkConfig) chunkPool[T] {
+	p := chunkPool[T]{
+		size:     config.chunkSize,
+		maxSpare: config.spareChunks,
+	}
+
+	if config.sharedPool {
+		key := chunkPoolKey{typ: reflect.TypeFor[T](), size: config.chunkSize}
+		pool, _ := sharedChunkPools.LoadOrStore(key, &sync.Pool{})
+		p.shared = pool.(*sync.Pool)
+	}
+	return p
+}
+
+// Return an empty chunk linked to next, reusing a spare chunk when there is one
+func (p *chunkPool[T]) get(next *arrnode[T]) *arrnode[T] {
+	var node *arrnode[T]
+	if p.free != nil {
+		node = p.free
+		p.free = node.next
+		p.spare--
+	} else if p.shared != nil {
+		node, _ = p.shared.Get().(*arrnode[T])
+	}
+
+	if node == nil {
+		return newArrayNode[T](next, p.size)
+	}
+	node.next = next
+	return node
+}
+
+// Take back a chunk the container no longer uses. It is kept as a spare, handed to the shared pool
+// or left to the garbage collector
+func (p *chunkPool[T]) put(node *arrnode[T]) {
+	if p.spare < p.maxSpare {
+		clear(node.data)
+		node.next = p.free
+		p.free = node
+		p.spare++
+		return
+	}
+
+	node.next = nil
+	if p.shared != nil {
+		clear(node.data)
+		p.shared.Put(node)
+	}
+}
+
+// Take back every chunk of the chain starting at node
+func (p *chunkPool[T]) putChain(node *arrnode[T]) {
+	for node != nil {
+		next := node.next
+		p.put(node)
+		node = next
+	}
+}
+
+// Return an empty pool with the same settings, for a cloned container
+func (p *chunkPool[T]) clone() chunkPool[T] {
+	return chunkPool[T]{
+		size:     p.size,
+		maxSpare: p.maxSpare,
+		shared:   p.shared,
+	}
+}
+
+// Release all spare chunks, to the shared pool if there is one
+func (p *chunkPool[T]) shrink() {
+	for p.free != nil {
+		node := p.free
+		p.free = node.next
+		node.next = nil
+		if p.shared != nil {
+			p.shared.Put(node)
+		}
+	}
+	p.spare = 0
+}
@@ -0,0 +1,164 @@
+package lists
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Interface for a source of time used by the time based containers. The default uses the system clock,
+// a ManualClock lets tests move time forward without sleeping.
+type Clock interface {
+	// Return the current time
+	Now() time.Time
+	// Call f once d has elapsed, on a goroutine other than the caller's
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Interface for a pending call scheduled with Clock.AfterFunc
+type Timer interface {
+	// Prevent the call from happening. Returns false if it already happened or was stopped
+	Stop() bool
+}
+
+// Clock backed by the time package
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// Struct holding the settings of a time based container
+type clockConfig struct {
+	clock Clock
+}
+
+// ClockOption configures a time based container (DelayQueue) when passed to its constructor
+type ClockOption func(*clockConfig)
+
+// Use the given clock instead of the system clock
+func WithClock(c Clock) ClockOption {
+	return func(config *clockConfig) {
+		if c != nil {
+			config.clock = c
+		}
+	}
+}
+
+// A hidden function which applies opts over the default settings
+func newClockConfig(opts []ClockOption) clockConfig {
+	c := clockConfig{clock: systemClock{}}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// The ManualClock is a Clock which only moves when told to. Calls scheduled with AfterFunc run when
+// Advance or Set moves the clock past their deadline, which makes time based code testable without sleeping.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+// A call scheduled on a ManualClock
+type manualTimer struct {
+	clock *ManualClock
+	when  time.Time
+	f     func()
+}
+
+// The constructor for a new ManualClock instance showing the time start.
+//
+// Returns a pointer to a ManualClock
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Return the time the clock shows
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Schedule f to run once the clock has moved d past the current time. If d is not positive, f runs straight
+// away in its own goroutine
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &manualTimer{clock: c, when: c.now.Add(d), f: f}
+	if d <= 0 {
+		go f()
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Return the number of calls scheduled with AfterFunc which have not run or been stopped yet.
+// Tests use it to wait until the code under test is blocked on the clock
+func (c *ManualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+// Move the clock forward by d and run every scheduled call whose deadline has been reached, in deadline order,
+// on the calling goroutine
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.set(c.now.Add(d))
+}
+
+// Move the clock to t and run every scheduled call whose deadline has been reached, in deadline order.
+// The clock never moves backwards
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.set(t)
+}
+
+// A hidden method which moves the clock to t. Must be called with the lock held, which it releases before
+// running the due calls so they can use the clock
+func (c *ManualClock) set(t time.Time) {
+	if t.After(c.now) {
+		c.now = t
+	}
+
+	due := make([]*manualTimer, 0)
+	c.timers = slices.DeleteFunc(c.timers, func(timer *manualTimer) bool {
+		if timer.when.After(c.now) {
+			return false
+		}
+		due = append(due, timer)
+		return true
+	})
+	c.mu.Unlock()
+
+	slices.SortStableFunc(due, func(a, b *manualTimer) int {
+		return a.when.Compare(b.when)
+	})
+	for _, timer := range due {
+		timer.f()
+	}
+}
+
+// Cancel the scheduled call. Returns false if it already ran or was stopped
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	n := len(t.clock.timers)
+	t.clock.timers = slices.DeleteFunc(t.clock.timers, func(timer *manualTimer) bool {
+		return timer == t
+	})
+	return len(t.clock.timers) < n
+}
@@ -1,6 +1,13 @@
 package lists
 
-const ListsVersion string = "1.4.0"
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+)
+
+const ListsVersion string = "2.0.0"
 
 // Interface for a Fifo list
 type Fifo[T any] interface {
@@ -8,10 +15,26 @@ type Fifo[T any] interface {
 	Count() uint
 	Dequeue() (T, error)
 	Enqueue(x T)
+	EnqueueAll(xs ...T)
+	EnqueueSlice(xs []T)
 	IsEmpty() bool
 	IsFull() bool
 	Peek() (T, error)
 	ToSlice() []T
+
+	// Iterate from the front to the back of the queue, in Dequeue order
+	All() iter.Seq[T]
+	// Iterate from the back to the front of the queue
+	Backward() iter.Seq[T]
+	// Iterate from the front to the back of the queue together with each element's position
+	Indexed() iter.Seq2[int, T]
+
+	// Dequeue elements while iterating until the queue is empty
+	Drain() iter.Seq[T]
+	// Dequeue up to n elements
+	DequeueN(n int) []T
+	// Dequeue up to len(dst) elements into dst and return how many were dequeued
+	DequeueInto(dst []T) int
 }
 
 // Interface for a Lifo list
@@ -19,21 +42,200 @@ type Lifo[T any] interface {
 	Count() uint
 	IsEmpty() bool
 	Push(x T)
+	PushAll(xs ...T)
 	Pop() (T, error)
 	Peek() (T, error)
 	ToSlice() []T
+
+	// Iterate from the top to the bottom of the stack, in Pop order
+	All() iter.Seq[T]
+	// Iterate from the bottom to the top of the stack
+	Backward() iter.Seq[T]
+	// Iterate from the top to the bottom of the stack together with each element's position
+	Indexed() iter.Seq2[int, T]
+
+	// Pop elements while iterating until the stack is empty
+	Drain() iter.Seq[T]
+	// Pop up to n elements
+	PopN(n int) []T
+	// Pop up to len(dst) elements into dst and return how many were popped
+	PopInto(dst []T) int
+}
+
+// Interface for a container which can release the memory it keeps around for reuse
+type Shrinker interface {
+	Shrink()
+}
+
+// Interface for a thread safe Fifo list whose consumers can block until an element is available.
+// If the list is also Closable, DequeueWait and PeekWait return ErrClosed once it is closed and empty,
+// and Enqueue, EnqueueAll and EnqueueSlice panic after Close
+type BlockingFifo[T any] interface {
+	Fifo[T]
+	DequeueWait(ctx context.Context) (T, error)
+	PeekWait(ctx context.Context) (T, error)
+}
+
+// Interface for a thread safe Lifo list whose consumers can block until an element is available.
+// If the list is also Closable, PopWait and PeekWait return ErrClosed once it is closed and empty,
+// and Push and PushAll panic after Close
+type BlockingLifo[T any] interface {
+	Lifo[T]
+	PopWait(ctx context.Context) (T, error)
+	PeekWait(ctx context.Context) (T, error)
+}
+
+// Interface for a thread safe container whose producers can signal that no more elements will come.
+// Once closed the elements left can still be taken, and waiting consumers return with ErrClosed as soon
+// as the container is empty. Adding elements fails: Enqueue and Push have no error result, so like a send
+// on a closed channel they panic with an error wrapping ErrClosed, while TryEnqueue and TryPush report it
+type Closable interface {
+	Close()
+	IsClosed() bool
+	Done() <-chan struct{}
+}
+
+// Struct tracking whether a container was closed. Closing must happen with the lock of the container held
+type closer struct {
+	done chan struct{}
+}
+
+// A hidden function which returns an open closer
+func newCloser() closer {
+	return closer{done: make(chan struct{})}
+}
+
+// A hidden method which reports whether the container was closed
+func (c *closer) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// A hidden method which marks the container closed. Must be called with the lock of the container held
+//
+// Returns false if it already was closed
+func (c *closer) close() bool {
+	if c.isClosed() {
+		return false
+	}
+	close(c.done)
+	return true
+}
+
+// Block on cond until ready returns true or ctx is done. The caller must hold cond.L, which is
+// held again when waitFor returns.
+//
+// Returns a *ContainerError for op on kind wrapping the context error if ctx is done before ready returns true
+func waitFor(ctx context.Context, kind, op string, cond *sync.Cond, ready func() bool) error {
+	if ready() {
+		return nil
+	}
+
+	// wake up every waiter once the context is done so this one can notice it
+	stop := context.AfterFunc(ctx, func() {
+		cond.L.Lock()
+		defer cond.L.Unlock()
+		cond.Broadcast()
+	})
+	defer stop()
+
+	for !ready() {
+		if err := ctx.Err(); err != nil {
+			return newContainerError(kind, op, err)
+		}
+		cond.Wait()
+	}
+	return nil
+}
+
+// The largest ring a power of two sized container can be built with
+const maxRingSize uint64 = 1 << 63
+
+// Return the smallest power of two which is at least size and at least least, for the ring of a power of
+// two sized container. Panics with a *ContainerError for op on kind wrapping ErrOutOfRange if size is above
+// maxRingSize, since no larger power of two fits in a uint64
+func ringSize(kind, op string, size uint, least uint64) uint64 {
+	if uint64(size) > maxRingSize {
+		panic(newContainerError(kind, op, ErrOutOfRange))
+	}
+	n := least
+	for n < uint64(size) {
+		n <<= 1
+	}
+	return n
+}
+
+// The size of a CPU cache line on the common platforms
+const cacheLineSize = 64
+
+// Padding placed between fields written by different goroutines so they don't share a cache line,
+// which would make every write by one goroutine invalidate the other's cached copy (false sharing)
+type cacheLinePad [cacheLineSize]byte
+
+// The number of elements held by each arrnode unless WithChunkSize is used
+const DefaultChunkSize int = 1000
+
+// Struct holding the settings of a container built on arrnode chunks
+type chunkConfig struct {
+	chunkSize   uint
+	spareChunks uint
+	sharedPool  bool
+}
+
+// ChunkOption configures a container built on arrnode chunks (Queue, SafeQueue, Stack, SafeStack, Deque
+// and SafeDeque) when passed to its constructor
+type ChunkOption func(*chunkConfig)
+
+// Set the number of elements held by each chunk. Small chunks suit small containers and large element
+// types, since a chunk is allocated in full as soon as it is needed. Values below 1 select DefaultChunkSize
+func WithChunkSize(n int) ChunkOption {
+	return func(c *chunkConfig) {
+		if n > 0 {
+			c.chunkSize = uint(n)
+		}
+	}
+}
+
+// Set how many drained chunks a container keeps for reuse instead of leaving them to the garbage collector.
+// Defaults to 1, which is enough for a steady stream of producers and consumers to stop allocating.
+// Spare chunks can be released with Shrink
+func WithSpareChunks(n int) ChunkOption {
+	return func(c *chunkConfig) {
+		c.spareChunks = uint(max(n, 0))
+	}
+}
+
+// Hand chunks beyond the spare chunk limit to a sync.Pool shared by all containers with the same element type
+// and chunk size, instead of leaving them to the garbage collector
+func WithSharedChunkPool() ChunkOption {
+	return func(c *chunkConfig) {
+		c.sharedPool = true
+	}
+}
+
+// A hidden function which applies opts over the default settings
+func newChunkConfig(opts []ChunkOption) chunkConfig {
+	c := chunkConfig{chunkSize: uint(DefaultChunkSize), spareChunks: 1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
 }
 
 // Struct for a single link node
 type arrnode[T any] struct {
-	data [1000]T
+	data []T
 	next *arrnode[T]
 }
 
-// Constructor for a single link node
-func newArrayNode[T any](n *arrnode[T]) *arrnode[T] {
+// Constructor for a single link node holding size elements
+func newArrayNode[T any](n *arrnode[T], size uint) *arrnode[T] {
 	return &arrnode[T]{
-		data: [1000]T{},
+		data: make([]T, size),
 		next: n,
 	}
 }
@@ -45,3 +247,124 @@ func (r *arrnode[T]) write(value T, pos int) {
 func (r *arrnode[T]) read(pos int) T {
 	return r.data[pos]
 }
+
+// Read the value at pos and clear the slot, so the chunk doesn't keep the value reachable
+func (r *arrnode[T]) take(pos int) T {
+	value := r.data[pos]
+	var zero T
+	r.data[pos] = zero
+	return value
+}
+
+// Copy the chain of arrnode chunks starting at node. The elements are copied by value.
+//
+// Returns the head and tail of the copy
+func cloneChain[T any](node *arrnode[T]) (head, tail *arrnode[T]) {
+	for ; node != nil; node = node.next {
+		c := &arrnode[T]{data: slices.Clone(node.data)}
+		if tail == nil {
+			head = c
+		} else {
+			tail.next = c
+		}
+		tail = c
+	}
+	return head, tail
+}
+
+// Return the chunk and slot of the element at offset i of an arrnode chain whose first element sits at
+// slot index of node. Complexity is O(i/chunk size)
+func locateChain[T any](node *arrnode[T], index, i uint) (*arrnode[T], int) {
+	p := index + i
+	size := uint(len(node.data))
+	for p >= size {
+		node = node.next
+		p -= size
+	}
+	return node, int(p)
+}
+
+// Return the position of the first element of seq satisfying f, or -1 if there is none
+func indexFunc[T any](seq iter.Seq2[int, T], f func(T) bool) int {
+	for i, v := range seq {
+		if f(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Walk count elements of an arrnode chain, starting at slot index of node and following next,
+// calling yield with the position and value of each element.
+//
+// Returns false if yield stopped the walk early
+func walkChain[T any](node *arrnode[T], index, count uint, yield func(int, T) bool) bool {
+	last := uint(len(node.data)) - 1
+
+	for i := uint(0); i < count; i++ {
+		if !yield(int(i), node.data[index]) {
+			return false
+		}
+
+		if index == last {
+			node = node.next
+			index = 0
+		} else {
+			index++
+		}
+	}
+	return true
+}
+
+// Walk count elements of an arrnode chain in reverse, ending at slot index of node,
+// calling yield with the value of each element.
+//
+// Returns false if yield stopped the walk early
+func walkChainBackward[T any](node *arrnode[T], index, count uint, yield func(T) bool) bool {
+	if count == 0 {
+		return true
+	}
+
+	// the chain is singly linked, so remember the nodes to step back through
+	size := uint(len(node.data))
+	nodes := make([]*arrnode[T], 0, (index+count)/size+1)
+	for n, last := node, (index+count-1)/size; uint(len(nodes)) <= last; n = n.next {
+		nodes = append(nodes, n)
+	}
+
+	for p := index + count - 1; ; p-- {
+		if !yield(nodes[p/size].data[p%size]) {
+			return false
+		}
+		if p == index {
+			break
+		}
+	}
+	return true
+}
+
+// Walk count elements of a ring buffer starting at index front, calling yield with the position
+// and value of each element.
+//
+// Returns false if yield stopped the walk early
+func walkRing[T any](data []T, front, count int, yield func(int, T) bool) bool {
+	for i := 0; i < count; i++ {
+		if !yield(i, data[(front+i)%len(data)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Walk count elements of a ring buffer in reverse, ending at index front, calling yield with
+// the value of each element.
+//
+// Returns false if yield stopped the walk early
+func walkRingBackward[T any](data []T, front, count int, yield func(T) bool) bool {
+	for i := count - 1; i >= 0; i-- {
+		if !yield(data[(front+i)%len(data)]) {
+			return false
+		}
+	}
+	return true
+}
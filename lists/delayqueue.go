@@ -0,0 +1,399 @@
+package lists
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+// Struct pairing an element of a DelayQueue with the time it becomes available
+type delayed[T any] struct {
+	value T
+	when  time.Time
+}
+
+// The DelayQueue is a collection of entities which only become available once their scheduled time has come.
+// Dequeue returns the element whose time came first, and nothing while no element is due yet. Elements
+// scheduled for the same time are dequeued in the order they were enqueued.
+//
+// DelayQueue is thread safe. However only the queue structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// DelayQueue is a list that implements the BlockingFifo interface. Enqueue makes an element available
+// straight away. Count and IsEmpty only consider due elements, so they agree with Dequeue, while
+// Scheduled counts every element and the iterators visit every element.
+type DelayQueue[T any] struct {
+	queue   *PriorityQueue[delayed[T]]
+	clock   Clock
+	timer   Timer // wakes up waiters once the earliest element is due, nil when not armed
+	armed   time.Time
+	waiters int // goroutines blocked in DequeueWait or PeekWait
+	mu      sync.RWMutex
+	cond    *sync.Cond // signalled whenever an element is enqueued or becomes due
+}
+
+// The constructor for a new DelayQueue instance with elements of type T.
+// WithClock replaces the system clock, for instance by a ManualClock in tests.
+//
+// Returns a pointer to a DelayQueue
+func NewDelayQueue[T any](opts ...ClockOption) *DelayQueue[T] {
+	config := newClockConfig(opts)
+	r := &DelayQueue[T]{
+		queue: NewPriorityQueue(func(a, b delayed[T]) bool { return a.when.Before(b.when) }),
+		clock: config.clock,
+	}
+	r.cond = sync.NewCond(&r.mu)
+	return r
+}
+
+// A hidden method which reports whether the earliest element is due. Must be called with the lock held
+func (r *DelayQueue[T]) isDue() bool {
+	front := r.queue.Front()
+	return front != nil && !front.Value.when.After(r.clock.Now())
+}
+
+// A hidden method which makes sure the timer wakes up the waiters once the earliest element is due.
+// Nothing is armed while nobody waits or the earliest element is due already. Must be called with the
+// lock held whenever the earliest element may have changed
+func (r *DelayQueue[T]) arm() {
+	front := r.queue.Front()
+	if r.waiters == 0 || front == nil || !front.Value.when.After(r.clock.Now()) {
+		return
+	}
+	if r.timer != nil && r.armed.Equal(front.Value.when) {
+		return
+	}
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	var timer Timer
+	timer = r.clock.AfterFunc(front.Value.when.Sub(r.clock.Now()), func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.timer == timer {
+			r.timer = nil
+		}
+		r.cond.Broadcast()
+	})
+	r.timer = timer
+	r.armed = front.Value.when
+}
+
+// A hidden method which stops the timer. Must be called with the lock held
+func (r *DelayQueue[T]) disarm() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+// A hidden method which blocks until an element is due or ctx is done, arming the timer while it waits.
+// Must be called with the lock held
+func (r *DelayQueue[T]) wait(ctx context.Context, op string) error {
+	r.waiters++
+	defer func() {
+		r.waiters--
+	}()
+
+	r.arm()
+	return waitFor(ctx, "DelayQueue", op, r.cond, r.isDue)
+}
+
+// A hidden method which schedules element for when. Must be called with the lock held
+func (r *DelayQueue[T]) enqueue(element T, when time.Time) {
+	r.queue.Push(delayed[T]{value: element, when: when})
+	r.arm()
+}
+
+// Return the number of elements in the queue. -1 means unlimited
+func (r *DelayQueue[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of due elements, the ones Dequeue can return right now. Complexity is O(k) for k due
+// elements
+func (r *DelayQueue[T]) Count() uint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.clock.Now()
+	return uint(r.queue.countWhile(func(element delayed[T]) bool {
+		return !element.when.After(now)
+	}))
+}
+
+// Return the number of elements in the queue, whether they are due or not
+func (r *DelayQueue[T]) Scheduled() uint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.queue.Count()
+}
+
+// Checks if the queue holds no due element. A queue holding only elements which are not due yet is empty,
+// as Dequeue has nothing to return
+//
+// Return true if empty false otherwise
+func (r *DelayQueue[T]) IsEmpty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return !r.isDue()
+}
+
+// Checks if the queue is full. Can never be full but just for interface implementation
+func (r *DelayQueue[T]) IsFull() bool {
+	return false
+}
+
+// Add an element of type T which becomes available at when. Complexity is O(log n)
+func (r *DelayQueue[T]) EnqueueAt(element T, when time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enqueue(element, when)
+	r.cond.Broadcast()
+}
+
+// Add an element of type T which becomes available once d has elapsed. Complexity is O(log n)
+func (r *DelayQueue[T]) EnqueueAfter(element T, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enqueue(element, r.clock.Now().Add(d))
+	r.cond.Broadcast()
+}
+
+// Add an element of type T which is available straight away. Complexity is O(log n)
+func (r *DelayQueue[T]) Enqueue(element T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enqueue(element, r.clock.Now())
+	r.cond.Broadcast()
+}
+
+// Add elements of type T which are available straight away, in the given order. Complexity is O(k log n)
+func (r *DelayQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice which are available straight away, in order.
+// The lock is taken once for the whole batch. Complexity is O(k log n)
+func (r *DelayQueue[T]) EnqueueSlice(elements []T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	for _, element := range elements {
+		r.enqueue(element, now)
+	}
+	if len(elements) > 0 {
+		r.cond.Broadcast()
+	}
+}
+
+// Remove and return the due element of type T which was scheduled first. Complexity is O(log n)
+//
+// Returns an error if no element is due
+func (r *DelayQueue[T]) Dequeue() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.dequeue()
+}
+
+// A hidden method which dequeues a due element. Must be called with the lock held
+func (r *DelayQueue[T]) dequeue() (T, error) {
+	if !r.isDue() {
+		var result T
+		return result, newContainerError("DelayQueue", "Dequeue", ErrEmpty)
+	}
+
+	element, _ := r.queue.Dequeue()
+	r.arm()
+	return element.value, nil
+}
+
+// Remove and return the element of type T which was scheduled first, blocking until it is due
+// or ctx is done. Complexity is O(log n)
+func (r *DelayQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.wait(ctx, "DequeueWait"); err != nil {
+		var result T
+		return result, err
+	}
+
+	return r.dequeue()
+}
+
+// Remove up to len(dst) due elements and copy them into dst in the order they were scheduled.
+// The lock is taken once for the whole batch. Complexity is O(k log n)
+//
+// Returns the number of elements copied
+func (r *DelayQueue[T]) DequeueInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for n < len(dst) && r.isDue() {
+		dst[n], _ = r.dequeue()
+		n++
+	}
+	return n
+}
+
+// Remove up to n due elements and return them in the order they were scheduled.
+// The lock is taken once for the whole batch. Complexity is O(n log n)
+func (r *DelayQueue[T]) DequeueN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make([]T, 0, min(max(n, 0), int(r.queue.Count())))
+	for len(s) < n && r.isDue() {
+		element, _ := r.dequeue()
+		s = append(s, element)
+	}
+	return s
+}
+
+// Return an iterator which dequeues due elements until none is left. Breaking out of the loop leaves the
+// remaining elements in the queue. Every element is dequeued under its own lock so producers are not held
+// up while the loop body runs; use DequeueN to take a batch at once.
+func (r *DelayQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return the due element of type T which was scheduled first without Dequeuing it. Complexity is O(1)
+//
+// Returns an error if no element is due
+func (r *DelayQueue[T]) Peek() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if !r.isDue() {
+		return result, newContainerError("DelayQueue", "Peek", ErrEmpty)
+	}
+
+	return r.queue.Front().Value.value, nil
+}
+
+// Return the element of type T which was scheduled first without Dequeuing it, blocking until it is due
+// or ctx is done. Complexity is O(1)
+func (r *DelayQueue[T]) PeekWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result T
+	if err := r.wait(ctx, "PeekWait"); err != nil {
+		return result, err
+	}
+
+	return r.queue.Front().Value.value, nil
+}
+
+// Return the time the earliest element becomes available, which may be in the past. Complexity is O(1)
+func (r *DelayQueue[T]) NextDeadline() (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	front := r.queue.Front()
+	if front == nil {
+		return time.Time{}, newContainerError("DelayQueue", "NextDeadline", ErrEmpty)
+	}
+	return front.Value.when, nil
+}
+
+// Remove all elements from the queue, whether they are due or not, and stop the timer. Complexity is O(n)
+func (r *DelayQueue[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queue.Clear()
+	r.disarm()
+}
+
+// Return a copy of the queue holding the same elements with the same schedule and clock. The elements
+// themselves are copied by value. The read lock is held while copying. Complexity is O(n)
+func (r *DelayQueue[T]) Clone() *DelayQueue[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := &DelayQueue[T]{
+		queue: r.queue.Clone(),
+		clock: r.clock,
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Return a slice representation of all elements of the queue, due or not, in the order they are scheduled.
+// Complexity is O(n log n)
+func (r *DelayQueue[T]) ToSlice() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := make([]T, 0, r.queue.Count())
+	for element := range r.queue.All() {
+		s = append(s, element.value)
+	}
+	return s
+}
+
+// Return an iterator over all elements of the queue, due or not, in the order they are scheduled.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *DelayQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		for element := range r.queue.All() {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over all elements of the queue, due or not, latest scheduled first.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *DelayQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		for element := range r.queue.Backward() {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the queue, due or not, in the order they are scheduled.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *DelayQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		for i, element := range r.queue.Indexed() {
+			if !yield(i, element.value) {
+				return
+			}
+		}
+	}
+}
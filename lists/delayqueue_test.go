@@ -0,0 +1,174 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Wait until n calls are scheduled on the clock, which means the code under test is blocked on it
+func waitPending(t *testing.T, clock *ManualClock, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Pending() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Pending() = %v, want %v", clock.Pending(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDelayQueue(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	var queue Fifo[string] = NewDelayQueue[string](WithClock(clock))
+	delay := queue.(*DelayQueue[string])
+
+	delay.EnqueueAfter("c", 3*time.Second)
+	delay.EnqueueAfter("a", time.Second)
+	delay.EnqueueAt("b", time.Unix(2, 0))
+	delay.EnqueueAfter("b2", 2*time.Second)
+
+	// nothing is due yet
+	if _, err := queue.Dequeue(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Dequeue() before the deadline error = %v, want %v", err, ErrEmpty)
+	}
+	if _, err := queue.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Peek() before the deadline error = %v, want %v", err, ErrEmpty)
+	}
+	if !queue.IsEmpty() || queue.Count() != 0 || delay.Scheduled() != 4 {
+		t.Errorf("IsEmpty(), Count(), Scheduled() = %v, %v, %v, want %v, %v, %v",
+			queue.IsEmpty(), queue.Count(), delay.Scheduled(), true, 0, 4)
+	}
+	if next, _ := delay.NextDeadline(); !next.Equal(time.Unix(1, 0)) {
+		t.Errorf("NextDeadline() = %v, want %v", next, time.Unix(1, 0))
+	}
+	if s := queue.ToSlice(); !slices.Equal(s, []string{"a", "b", "b2", "c"}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []string{"a", "b", "b2", "c"})
+	}
+
+	clock.Advance(time.Second)
+	if queue.IsEmpty() || queue.Count() != 1 {
+		t.Errorf("IsEmpty(), Count() = %v, %v, want %v, %v", queue.IsEmpty(), queue.Count(), false, 1)
+	}
+	if element, err := queue.Dequeue(); element != "a" || err != nil {
+		t.Errorf("Dequeue() = %v, %v, want %v, %v", element, err, "a", nil)
+	}
+	if _, err := queue.Dequeue(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Dequeue() before the deadline error = %v, want %v", err, ErrEmpty)
+	}
+
+	// elements due at the same time come out in the order they were enqueued
+	clock.Advance(5 * time.Second)
+	if queue.Count() != 3 {
+		t.Errorf("Count() = %v, want %v", queue.Count(), 3)
+	}
+	if s := queue.DequeueN(10); !slices.Equal(s, []string{"b", "b2", "c"}) {
+		t.Errorf("DequeueN() = %v, want %v", s, []string{"b", "b2", "c"})
+	}
+
+	// Enqueue makes an element available straight away
+	queue.EnqueueAll("x", "y")
+	delay.EnqueueAfter("z", time.Second)
+	if s := slices.Collect(queue.Drain()); !slices.Equal(s, []string{"x", "y"}) {
+		t.Errorf("Drain() = %v, want %v", s, []string{"x", "y"})
+	}
+	if !queue.IsEmpty() || delay.Scheduled() != 1 {
+		t.Errorf("IsEmpty(), Scheduled() = %v, %v, want %v, %v", queue.IsEmpty(), delay.Scheduled(), true, 1)
+	}
+
+	clone := delay.Clone()
+	delay.Clear()
+	if delay.Scheduled() != 0 || clone.Scheduled() != 1 {
+		t.Errorf("Scheduled() after Clear() = %v, Clone().Scheduled() = %v, want %v, %v", delay.Scheduled(), clone.Scheduled(), 0, 1)
+	}
+	clock.Advance(time.Second)
+	if element, _ := clone.Dequeue(); element != "z" {
+		t.Errorf("Clone().Dequeue() = %v, want %v", element, "z")
+	}
+}
+
+func TestDelayQueueDequeueWait(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	queue := NewDelayQueue[int](WithClock(clock))
+
+	// a waiting consumer is woken up once the deadline passes
+	queue.EnqueueAfter(1, time.Minute)
+	result := make(chan int)
+	go func() {
+		element, _ := queue.DequeueWait(context.Background())
+		result <- element
+	}()
+	waitPending(t, clock, 1)
+	clock.Advance(59 * time.Second)
+	select {
+	case element := <-result:
+		t.Fatalf("DequeueWait() = %v before the deadline", element)
+	case <-time.After(10 * time.Millisecond):
+	}
+	clock.Advance(time.Second)
+	if element := <-result; element != 1 {
+		t.Errorf("DequeueWait() = %v, want %v", element, 1)
+	}
+
+	// an earlier element enqueued while a consumer waits moves its wake up forward
+	queue.EnqueueAfter(2, time.Hour)
+	go func() {
+		element, _ := queue.PeekWait(context.Background())
+		result <- element
+	}()
+	waitPending(t, clock, 1)
+	queue.EnqueueAfter(3, time.Second)
+	waitPending(t, clock, 1)
+	clock.Advance(time.Second)
+	if element := <-result; element != 3 {
+		t.Errorf("PeekWait() = %v, want %v", element, 3)
+	}
+
+	// waiting stops with the context
+	queue.Dequeue()
+	ctx, cancel := context.WithCancel(context.Background())
+	failed := make(chan error)
+	go func() {
+		_, err := queue.DequeueWait(ctx)
+		failed <- err
+	}()
+	waitPending(t, clock, 1)
+	cancel()
+	if err := <-failed; !errors.Is(err, context.Canceled) {
+		t.Errorf("DequeueWait() error = %v, want %v", err, context.Canceled)
+	}
+	if queue.Count() != 0 || queue.Scheduled() != 1 {
+		t.Errorf("Count(), Scheduled() = %v, %v, want %v, %v", queue.Count(), queue.Scheduled(), 0, 1)
+	}
+
+	// clearing stops the timer of a waiting consumer
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		_, err := queue.DequeueWait(ctx)
+		failed <- err
+	}()
+	waitPending(t, clock, 1)
+	queue.Clear()
+	if pending := clock.Pending(); pending != 0 {
+		t.Errorf("Pending() after Clear() = %v, want %v", pending, 0)
+	}
+	cancel()
+	<-failed
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	queue := NewDelayQueue[int]()
+	queue.EnqueueAfter(1, 20*time.Millisecond)
+
+	start := time.Now()
+	element, err := queue.DequeueWait(context.Background())
+	if element != 1 || err != nil {
+		t.Errorf("DequeueWait() = %v, %v, want %v, %v", element, err, 1, nil)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("DequeueWait() returned after %v, want at least %v", elapsed, 20*time.Millisecond)
+	}
+}
@@ -0,0 +1,385 @@
+package lists
+
+import (
+	"iter"
+	"slices"
+)
+
+// The Deque is a double ended queue: a sequence of entities that can be added to and removed from both
+// the front and the back in O(1).
+//
+// Like Queue and Stack it stores its elements in arrnode chunks. The chunks are addressed through a chunk map
+// (a slice of chunk pointers with free room at both ends) which also makes indexed access with At O(1).
+//
+// Deque is a list that implements the Fifo interface, where Enqueue pushes to the back and Dequeue pops from
+// the front.
+type Deque[T any] struct {
+	curBuffSize uint
+	headIndex   uint // index of the front element within chunks[first]
+	first       int  // index in chunks of the chunk holding the front element
+	chunkSize   uint
+	pool        chunkPool[T]
+	chunks      []*arrnode[T]
+	kind        string // the container type named by errors, "SafeDeque" inside a SafeDeque
+}
+
+// The constructor for a new Deque instance with elements of type T.
+// WithChunkSize changes the number of elements stored per chunk.
+//
+// Returns a pointer to a Deque
+func NewDeque[T any](opts ...ChunkOption) *Deque[T] {
+	config := newChunkConfig(opts)
+	r := &Deque[T]{
+		chunkSize: config.chunkSize,
+		pool:      newChunkPool[T](config),
+		chunks:    make([]*arrnode[T], 3),
+		kind:      "Deque",
+	}
+	r.reset()
+	return r
+}
+
+// A hidden method which recenters an empty deque so it can grow in both directions without
+// touching the chunk map
+func (r *Deque[T]) reset() {
+	node := r.chunks[r.first]
+	if node == nil {
+		node = r.pool.get(nil)
+	}
+	r.chunks[r.first] = nil
+
+	r.first = len(r.chunks) / 2
+	r.chunks[r.first] = node
+	r.headIndex = r.chunkSize / 2
+}
+
+// A hidden method which returns the chunk and slot of the element at offset i from the front
+func (r *Deque[T]) locate(i uint) (*arrnode[T], int) {
+	p := r.headIndex + i
+	return r.chunks[r.first+int(p/r.chunkSize)], int(p % r.chunkSize)
+}
+
+// A hidden method which makes free room at both ends of the chunk map, keeping the chunks in use centered.
+// The map is only reallocated when it is short of room, otherwise the chunks in use, which drifted to one
+// end, are moved back to the middle
+func (r *Deque[T]) growMap() {
+	used := 1
+	if r.curBuffSize > 0 {
+		used = int((r.headIndex+r.curBuffSize-1)/r.chunkSize) + 1
+	}
+
+	if len(r.chunks) > 2*used {
+		first := (len(r.chunks) - used) / 2
+		copy(r.chunks[first:first+used], r.chunks[r.first:r.first+used])
+		clear(r.chunks[:first])
+		clear(r.chunks[first+used:])
+		r.first = first
+		return
+	}
+
+	chunks := make([]*arrnode[T], 2*len(r.chunks)+used)
+	first := (len(chunks) - used) / 2
+	copy(chunks[first:], r.chunks[r.first:r.first+used])
+
+	r.chunks = chunks
+	r.first = first
+}
+
+// Return the number of elements in the deque. -1 means unlimited
+func (r *Deque[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of elements in the deque
+func (r *Deque[T]) Count() uint {
+	return r.curBuffSize
+}
+
+// Checks if the deque is empty
+//
+// Return true if empty false otherwise
+func (r *Deque[T]) IsEmpty() bool {
+	return r.curBuffSize == 0
+}
+
+// Checks if the deque is full. Can never be full but just for interface implementation
+func (r *Deque[T]) IsFull() bool {
+	return false
+}
+
+// Add an element of type T to the front of the deque. Complexity is O(1)
+func (r *Deque[T]) PushFront(element T) {
+	if r.headIndex == 0 {
+		if r.first == 0 {
+			r.growMap()
+		}
+		r.first--
+		if r.chunks[r.first] == nil {
+			r.chunks[r.first] = r.pool.get(nil)
+		}
+		r.headIndex = r.chunkSize - 1
+	} else {
+		r.headIndex--
+	}
+
+	r.chunks[r.first].write(element, int(r.headIndex))
+	r.curBuffSize++
+}
+
+// Add an element of type T to the back of the deque. Complexity is O(1)
+func (r *Deque[T]) PushBack(element T) {
+	p := r.headIndex + r.curBuffSize
+	if r.first+int(p/r.chunkSize) >= len(r.chunks) {
+		r.growMap()
+	}
+
+	c := r.first + int(p/r.chunkSize)
+	if r.chunks[c] == nil {
+		r.chunks[c] = r.pool.get(nil)
+	}
+
+	r.chunks[c].write(element, int(p%r.chunkSize))
+	r.curBuffSize++
+}
+
+// Add elements of type T to the back of the deque in the given order. Complexity is O(k)
+func (r *Deque[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the back of the deque in order. Complexity is O(k)
+func (r *Deque[T]) EnqueueSlice(elements []T) {
+	for _, element := range elements {
+		r.PushBack(element)
+	}
+}
+
+// Remove and return the element of type T at the front of the deque. Complexity is O(1)
+func (r *Deque[T]) PopFront() (T, error) {
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError(r.kind, "PopFront", ErrEmpty)
+	}
+
+	result = r.chunks[r.first].take(int(r.headIndex))
+	r.curBuffSize--
+
+	if r.curBuffSize == 0 {
+		r.reset()
+	} else if r.headIndex == r.chunkSize-1 {
+		r.pool.put(r.chunks[r.first])
+		r.chunks[r.first] = nil
+		r.first++
+		r.headIndex = 0
+	} else {
+		r.headIndex++
+	}
+
+	return result, nil
+}
+
+// Remove and return the element of type T at the back of the deque. Complexity is O(1)
+func (r *Deque[T]) PopBack() (T, error) {
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError(r.kind, "PopBack", ErrEmpty)
+	}
+
+	p := r.headIndex + r.curBuffSize - 1
+	c := r.first + int(p/r.chunkSize)
+	result = r.chunks[c].take(int(p % r.chunkSize))
+	r.curBuffSize--
+
+	if r.curBuffSize == 0 {
+		r.reset()
+	} else if p%r.chunkSize == 0 {
+		r.pool.put(r.chunks[c])
+		r.chunks[c] = nil
+	}
+
+	return result, nil
+}
+
+// Remove up to len(dst) elements from the front of the deque and copy them into dst. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *Deque[T]) DequeueInto(dst []T) int {
+	n := 0
+	for n < len(dst) && r.curBuffSize > 0 {
+		dst[n], _ = r.PopFront()
+		n++
+	}
+	return n
+}
+
+// Remove up to n elements from the front of the deque and return them. Complexity is O(n)
+func (r *Deque[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.DequeueInto(s)]
+}
+
+// Return an iterator which pops elements from the front until the deque is empty.
+// Breaking out of the loop leaves the remaining elements in the deque
+func (r *Deque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r.curBuffSize > 0 {
+			element, _ := r.PopFront()
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return the element of type T at the front of the deque without removing it. Complexity is O(1)
+func (r *Deque[T]) PeekFront() (T, error) {
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError(r.kind, "PeekFront", ErrEmpty)
+	}
+
+	return r.chunks[r.first].read(int(r.headIndex)), nil
+}
+
+// Return the element of type T at the back of the deque without removing it. Complexity is O(1)
+func (r *Deque[T]) PeekBack() (T, error) {
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError(r.kind, "PeekBack", ErrEmpty)
+	}
+
+	return r.At(int(r.curBuffSize) - 1)
+}
+
+// Return the element of type T at position i counted from the front of the deque. Complexity is O(1)
+func (r *Deque[T]) At(i int) (T, error) {
+	var result T
+	if err := checkIndex(r.kind, "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	node, pos := r.locate(uint(i))
+	return node.read(pos), nil
+}
+
+// Replace the element at position i counted from the front of the deque. Complexity is O(1)
+func (r *Deque[T]) Set(i int, element T) error {
+	if err := checkIndex(r.kind, "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	node, pos := r.locate(uint(i))
+	node.write(element, pos)
+	return nil
+}
+
+// Add an element of type T to the back of the deque. Same as PushBack
+func (r *Deque[T]) Enqueue(element T) {
+	r.PushBack(element)
+}
+
+// Remove and return the element of type T at the front of the deque. Same as PopFront
+func (r *Deque[T]) Dequeue() (T, error) {
+	return r.PopFront()
+}
+
+// Return the element of type T at the front of the deque without removing it. Same as PeekFront
+func (r *Deque[T]) Peek() (T, error) {
+	return r.PeekFront()
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// Complexity is O(n)
+func (r *Deque[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the deque satisfies f. Complexity is O(n)
+func (r *Deque[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the deque from front to back
+func (r *Deque[T]) ToSlice() []T {
+	s := make([]T, 0, r.curBuffSize)
+	for v := range r.All() {
+		s = append(s, v)
+	}
+	return s
+}
+
+// Return an iterator over the elements of the deque from front to back
+func (r *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.Indexed() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the deque from back to front
+func (r *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := r.curBuffSize; i > 0; i-- {
+			node, pos := r.locate(i - 1)
+			if !yield(node.read(pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the deque from front to back
+func (r *Deque[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := uint(0); i < r.curBuffSize; i++ {
+			node, pos := r.locate(i)
+			if !yield(int(i), node.read(pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Remove all elements from the deque. One chunk is kept and the others are recycled, so a cleared
+// deque can be refilled without allocating. Complexity is O(n)
+func (r *Deque[T]) Clear() {
+	for i, node := range r.chunks {
+		if node != nil && i != r.first {
+			r.pool.put(node)
+			r.chunks[i] = nil
+		}
+	}
+	clear(r.chunks[r.first].data)
+
+	r.curBuffSize = 0
+	r.reset()
+}
+
+// Return a copy of the deque which shares no chunks with it. The elements themselves are copied by value.
+// Complexity is O(n)
+func (r *Deque[T]) Clone() *Deque[T] {
+	chunks := make([]*arrnode[T], len(r.chunks))
+	for i, node := range r.chunks {
+		if node != nil {
+			chunks[i] = &arrnode[T]{data: slices.Clone(node.data)}
+		}
+	}
+
+	return &Deque[T]{
+		curBuffSize: r.curBuffSize,
+		headIndex:   r.headIndex,
+		first:       r.first,
+		chunkSize:   r.chunkSize,
+		pool:        r.pool.clone(),
+		chunks:      chunks,
+		kind:        r.kind,
+	}
+}
+
+// Release the spare chunks kept for reuse, see WithSpareChunks
+func (r *Deque[T]) Shrink() {
+	r.pool.shrink()
+}
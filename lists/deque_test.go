@@ -0,0 +1,135 @@
+package lists
+
+import (
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	var _ Fifo[int] = NewDeque[int]()
+	deque := NewDeque[int]()
+
+	// test if empty
+	if !deque.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", deque.IsEmpty(), true)
+	}
+
+	// pop from an empty deque
+	_, err := deque.PopFront()
+	if err == nil {
+		t.Errorf("PopFront() = %v, want %v", err, "empty list")
+	}
+	_, err = deque.PopBack()
+	if err == nil {
+		t.Errorf("PopBack() = %v, want %v", err, "empty list")
+	}
+
+	// push 2500 elements on each side so both ends cross several chunks
+	for i := 0; i < 2500; i++ {
+		deque.PushBack(i)
+		deque.PushFront(-i - 1)
+	}
+
+	// check count method
+	if deque.Count() != 5000 {
+		t.Errorf("Count() = %v, want %v", deque.Count(), 5000)
+	}
+
+	// indexed access
+	for i := 0; i < 5000; i++ {
+		element, err := deque.At(i)
+		if element != i-2500 || err != nil {
+			t.Errorf("At(%v) = %v, %v, want %v, %v", i, element, err, i-2500, nil)
+		}
+	}
+	if _, err := deque.At(5000); err == nil {
+		t.Errorf("At(%v) = %v, want %v", 5000, err, "index out of range")
+	}
+
+	element, _ := deque.PeekFront()
+	if element != -2500 {
+		t.Errorf("PeekFront() = %v, want %v", element, -2500)
+	}
+	element, _ = deque.PeekBack()
+	if element != 2499 {
+		t.Errorf("PeekBack() = %v, want %v", element, 2499)
+	}
+
+	// pop from both ends
+	for i := 0; i < 2000; i++ {
+		if element, _ := deque.PopFront(); element != i-2500 {
+			t.Errorf("PopFront() = %v, want %v", element, i-2500)
+		}
+		if element, _ := deque.PopBack(); element != 2499-i {
+			t.Errorf("PopBack() = %v, want %v", element, 2499-i)
+		}
+	}
+
+	s := deque.ToSlice()
+	if len(s) != 1000 || s[0] != -500 || s[999] != 499 {
+		t.Errorf("ToSlice() = %v, want %v elements from %v to %v", len(s), 1000, -500, 499)
+	}
+
+	// drain from the back and reuse
+	for !deque.IsEmpty() {
+		deque.PopBack()
+	}
+	deque.Enqueue(1)
+	deque.Enqueue(2)
+	if element, _ := deque.Dequeue(); element != 1 {
+		t.Errorf("Dequeue() = %v, want %v", element, 1)
+	}
+}
+
+func TestDequeChunkMapBounded(t *testing.T) {
+	for name, push := range map[string]func(d *Deque[int], x int){
+		"PushBack":  (*Deque[int]).PushBack,
+		"PushFront": (*Deque[int]).PushFront,
+	} {
+		deque := NewDeque[int](WithChunkSize(4))
+		for i := 0; i < 8; i++ {
+			push(deque, i)
+		}
+
+		// a sliding window of 8 elements moves through the chunk map, which is recentered instead of grown
+		for i := 8; i < 200000; i++ {
+			push(deque, i)
+			if name == "PushBack" {
+				deque.PopFront()
+			} else {
+				deque.PopBack()
+			}
+		}
+		if deque.Count() != 8 || len(deque.chunks) > 16 {
+			t.Errorf("%v Count(), len(chunks) = %v, %v, want %v, at most %v", name, deque.Count(), len(deque.chunks), 8, 16)
+		}
+	}
+}
+
+func BenchmarkDequePushBack(b *testing.B) {
+	deque := NewDeque[int]()
+
+	for i := 0; i < b.N; i++ {
+		deque.PushBack(i)
+	}
+}
+
+func BenchmarkDequePushFront(b *testing.B) {
+	deque := NewDeque[int]()
+
+	for i := 0; i < b.N; i++ {
+		deque.PushFront(i)
+	}
+}
+
+func BenchmarkDequePopFront(b *testing.B) {
+	deque := NewDeque[int]()
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		deque.PushBack(i)
+	}
+
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		deque.PopFront()
+	}
+}
@@ -0,0 +1,50 @@
+package lists
+
+import "errors"
+
+var (
+	// ErrEmpty is returned when an element is requested from a container holding none
+	ErrEmpty = errors.New("empty list")
+	// ErrFull is returned when an element is refused because the container reached its capacity
+	ErrFull = errors.New("full list")
+	// ErrClosed is returned when a container was closed
+	ErrClosed = errors.New("closed list")
+	// ErrOutOfRange is returned when an index does not address an element of the container
+	ErrOutOfRange = errors.New("index out of range")
+	// ErrNotFound is returned when a key does not address an element of the container
+	ErrNotFound = errors.New("key not found")
+)
+
+// ContainerError describes a failed operation on a container. It wraps one of the sentinel errors
+// so callers can test for the cause with errors.Is, and errors.As gives access to the details.
+type ContainerError struct {
+	Kind string // the container type, e.g. "Queue"
+	Op   string // the operation that failed, e.g. "Dequeue"
+	Err  error  // the cause, one of ErrEmpty, ErrFull, ErrClosed, ErrOutOfRange, ErrNotFound or a context error
+}
+
+// A hidden function which builds a *ContainerError
+func newContainerError(kind, op string, err error) error {
+	return &ContainerError{Kind: kind, Op: op, Err: err}
+}
+
+// A hidden function which checks that i is the position of an element of a container holding count elements
+func checkIndex(kind, op string, i int, count uint) error {
+	if count == 0 {
+		return newContainerError(kind, op, ErrEmpty)
+	}
+	if i < 0 || uint(i) >= count {
+		return newContainerError(kind, op, ErrOutOfRange)
+	}
+	return nil
+}
+
+// Return the error message in the form "Kind.Op: cause"
+func (e *ContainerError) Error() string {
+	return e.Kind + "." + e.Op + ": " + e.Err.Error()
+}
+
+// Return the sentinel error which caused e
+func (e *ContainerError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,58 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestContainerErrors(t *testing.T) {
+	tests := []struct {
+		kind string
+		op   string
+		call func() error
+		want error
+	}{
+		{"Queue", "Dequeue", func() error { _, err := NewQueue[int]().Dequeue(); return err }, ErrEmpty},
+		{"Queue", "Peek", func() error { _, err := NewQueue[int]().Peek(); return err }, ErrEmpty},
+		{"LSQueue", "Dequeue", func() error { _, err := NewLSQueue[int](1).Dequeue(); return err }, ErrEmpty},
+		{"LSQueue", "TryEnqueue", func() error { return NewLSQueue(0, WithOverflowPolicy[int](Reject)).TryEnqueue(1) }, ErrFull},
+		{"SafeQueue", "Dequeue", func() error { _, err := NewSafeQueue[int]().Dequeue(); return err }, ErrEmpty},
+		{"SafeLSQueue", "Peek", func() error { _, err := NewSafeLSQueue[int](1).Peek(); return err }, ErrEmpty},
+		{"Stack", "Pop", func() error { _, err := NewStack[int]().Pop(); return err }, ErrEmpty},
+		{"SafeStack", "Peek", func() error { _, err := NewSafeStack[int]().Peek(); return err }, ErrEmpty},
+		{"Deque", "At", func() error { d := NewDeque[int](); d.PushBack(1); _, err := d.At(1); return err }, ErrOutOfRange},
+		{"SafePriorityQueue", "Dequeue", func() error { _, err := NewSafeOrderedPriorityQueue[int]().Dequeue(); return err }, ErrEmpty},
+		{"SafePriorityQueue", "Peek", func() error { _, err := NewSafeOrderedPriorityQueue[int]().Peek(); return err }, ErrEmpty},
+		{"SafeDeque", "PopBack", func() error { _, err := NewSafeDeque[int]().PopBack(); return err }, ErrEmpty},
+		{"SafeDeque", "PeekFront", func() error { _, err := NewSafeDeque[int]().PeekFront(); return err }, ErrEmpty},
+		{"SafeDeque", "Set", func() error { d := NewSafeDeque[int](); d.PushBack(1); return d.Set(-1, 0) }, ErrOutOfRange},
+		{"SafeQueue", "DequeueWait", func() error {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := NewSafeQueue[int]().DequeueWait(ctx)
+			return err
+		}, context.Canceled},
+		{"BoundedQueue", "TryEnqueue", func() error { q := NewBoundedQueue[int](1); q.Enqueue(1); return q.TryEnqueue(2) }, ErrFull},
+		{"BoundedQueue", "EnqueueCtx", func() error {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			q := NewBoundedQueue[int](1)
+			q.Enqueue(1)
+			return q.EnqueueCtx(ctx, 2)
+		}, context.Canceled},
+		{"IndexedHeap", "Remove", func() error { _, err := NewOrderedIndexedHeap[string, int]().Remove("a"); return err }, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		err := tt.call()
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%v.%v() = %v, want %v", tt.kind, tt.op, err, tt.want)
+		}
+
+		var cerr *ContainerError
+		if !errors.As(err, &cerr) || cerr.Kind != tt.kind || cerr.Op != tt.op {
+			t.Errorf("%v.%v() = %#v, want a *ContainerError for %v.%v", tt.kind, tt.op, err, tt.kind, tt.op)
+		}
+	}
+}
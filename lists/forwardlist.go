@@ -0,0 +1,327 @@
+package lists
+
+import "iter"
+
+// ForwardElement is a handle to a single entry of a ForwardList. It only links to the next entry
+// which keeps the per element overhead to a single pointer.
+type ForwardElement[T any] struct {
+	next *ForwardElement[T]
+
+	// The value stored in this element
+	Value T
+}
+
+// Return the next element of the list or nil if e is the last element
+func (e *ForwardElement[T]) Next() *ForwardElement[T] {
+	return e.next
+}
+
+// The ForwardList is a singly linked list of elements of type T. Since elements only know their successor,
+// positional operations work on the element *after* a given position. Wherever a position is taken, passing
+// nil refers to the position before the first element, so InsertAfter(x, nil) is the same as PushFront(x).
+//
+// Elements passed as positions must belong to the list the method is called on (or to other where noted).
+// Unlike List this is not verified, as doing so would require a back pointer in every element.
+//
+// The zero value of a ForwardList is an empty list ready to use.
+type ForwardList[T any] struct {
+	head        *ForwardElement[T]
+	tail        *ForwardElement[T]
+	curBuffSize uint
+	dirty       bool // curBuffSize must be recounted after a range splice
+}
+
+// The constructor for a new ForwardList instance with elements of type T.
+//
+// Returns a pointer to a ForwardList
+func NewForwardList[T any]() *ForwardList[T] {
+	return &ForwardList[T]{}
+}
+
+// Return the number of elements in the list. Complexity is O(1), except for the first call after a
+// SpliceRangeAfter which recounts the list in O(n)
+func (r *ForwardList[T]) Count() uint {
+	if r.dirty {
+		r.curBuffSize = 0
+		for e := r.head; e != nil; e = e.next {
+			r.curBuffSize++
+		}
+		r.dirty = false
+	}
+	return r.curBuffSize
+}
+
+// Checks if the list is empty
+//
+// Return true if empty false otherwise
+func (r *ForwardList[T]) IsEmpty() bool {
+	return r.head == nil
+}
+
+// Return the first element of the list or nil if the list is empty
+func (r *ForwardList[T]) Front() *ForwardElement[T] {
+	return r.head
+}
+
+// Return the last element of the list or nil if the list is empty
+func (r *ForwardList[T]) Back() *ForwardElement[T] {
+	return r.tail
+}
+
+// Insert a new element with the given value at the front of the list and return it. Complexity is O(1)
+func (r *ForwardList[T]) PushFront(value T) *ForwardElement[T] {
+	return r.InsertAfter(value, nil)
+}
+
+// Insert a new element with the given value at the back of the list and return it. Complexity is O(1)
+func (r *ForwardList[T]) PushBack(value T) *ForwardElement[T] {
+	return r.InsertAfter(value, r.tail)
+}
+
+// Remove and return the first element's value. Complexity is O(1)
+func (r *ForwardList[T]) PopFront() (T, error) {
+	if r.head == nil {
+		var result T
+		return result, newContainerError("ForwardList", "PopFront", ErrEmpty)
+	}
+	return r.EraseAfter(nil), nil
+}
+
+// Insert a new element with the given value immediately after pos and return it.
+// A nil pos inserts at the front of the list. Complexity is O(1)
+func (r *ForwardList[T]) InsertAfter(value T, pos *ForwardElement[T]) *ForwardElement[T] {
+	e := &ForwardElement[T]{Value: value}
+
+	if pos == nil {
+		e.next = r.head
+		r.head = e
+	} else {
+		e.next = pos.next
+		pos.next = e
+	}
+
+	if e.next == nil {
+		r.tail = e
+	}
+	r.curBuffSize++
+	return e
+}
+
+// Remove the element immediately after pos and return its value. A nil pos removes the first element.
+// If there is no element after pos, the list is not modified and the zero value is returned. Complexity is O(1)
+func (r *ForwardList[T]) EraseAfter(pos *ForwardElement[T]) T {
+	var e *ForwardElement[T]
+	if pos == nil {
+		e = r.head
+	} else {
+		e = pos.next
+	}
+
+	if e == nil {
+		var result T
+		return result
+	}
+
+	if pos == nil {
+		r.head = e.next
+	} else {
+		pos.next = e.next
+	}
+
+	if r.tail == e {
+		r.tail = pos
+	}
+	e.next = nil // avoid memory leaks
+	r.curBuffSize--
+	return e.Value
+}
+
+// Move all elements of other immediately after pos, leaving other empty. A nil pos splices at the front
+// of the list. Complexity is O(1)
+func (r *ForwardList[T]) SpliceAfter(pos *ForwardElement[T], other *ForwardList[T]) {
+	if other == r || other.head == nil {
+		return
+	}
+
+	n := other.Count()
+	r.link(pos, other.head, other.tail)
+	r.curBuffSize += n
+
+	other.head = nil
+	other.tail = nil
+	other.curBuffSize = 0
+	other.dirty = false
+}
+
+// Move the elements of other in the range (first, last] immediately after pos. That is, the elements
+// following first up to and including last. A nil first refers to the position before other's first element.
+// The range must be non empty and last must be reachable from first. other may be the list itself, as long as
+// pos is not inside the range.
+//
+// The links are rewired in O(1). The element count of both lists is recomputed on the next call to Count.
+func (r *ForwardList[T]) SpliceRangeAfter(pos *ForwardElement[T], other *ForwardList[T], first, last *ForwardElement[T]) {
+	if pos == first || pos == last {
+		return
+	}
+
+	var begin *ForwardElement[T]
+	if first == nil {
+		begin = other.head
+		other.head = last.next
+	} else {
+		begin = first.next
+		first.next = last.next
+	}
+
+	if other.tail == last {
+		other.tail = first
+	}
+
+	r.link(pos, begin, last)
+
+	other.dirty = true
+	r.dirty = true
+}
+
+// A hidden method which links the chain begin..end immediately after pos
+func (r *ForwardList[T]) link(pos, begin, end *ForwardElement[T]) {
+	if pos == nil {
+		end.next = r.head
+		r.head = begin
+	} else {
+		end.next = pos.next
+		pos.next = begin
+	}
+
+	if end.next == nil {
+		r.tail = end
+	}
+}
+
+// Reverse the order of the elements in place. Complexity is O(n)
+func (r *ForwardList[T]) Reverse() {
+	var prev *ForwardElement[T]
+	cur := r.head
+	r.tail = cur
+
+	for cur != nil {
+		next := cur.next
+		cur.next = prev
+		prev = cur
+		cur = next
+	}
+	r.head = prev
+}
+
+// Merge the elements of other into the list, leaving other empty. Both lists must already be sorted
+// according to less. The merge is stable: for equivalent elements, those of the list precede those of other.
+// Complexity is O(n+m)
+func (r *ForwardList[T]) Merge(other *ForwardList[T], less func(a, b T) bool) {
+	if other == r || other.head == nil {
+		return
+	}
+
+	n := r.Count() + other.Count()
+	a, b := r.head, other.head
+	var head, tail *ForwardElement[T]
+
+	for a != nil && b != nil {
+		var e *ForwardElement[T]
+		if less(b.Value, a.Value) {
+			e, b = b, b.next
+		} else {
+			e, a = a, a.next
+		}
+
+		if tail == nil {
+			head = e
+		} else {
+			tail.next = e
+		}
+		tail = e
+	}
+
+	rest, restTail := a, r.tail
+	if b != nil {
+		rest, restTail = b, other.tail
+	}
+	if tail == nil {
+		head = rest
+	} else {
+		tail.next = rest
+	}
+	if rest != nil {
+		tail = restTail
+	}
+
+	r.head = head
+	r.tail = tail
+	r.curBuffSize = n
+
+	other.head = nil
+	other.tail = nil
+	other.curBuffSize = 0
+}
+
+// Remove all elements from the list. Complexity is O(1)
+func (r *ForwardList[T]) Clear() {
+	r.head = nil
+	r.tail = nil
+	r.curBuffSize = 0
+	r.dirty = false
+}
+
+// Return a copy of the list with new elements holding the same values. The values themselves are copied
+// by value. Complexity is O(n)
+func (r *ForwardList[T]) Clone() *ForwardList[T] {
+	c := NewForwardList[T]()
+	for e := r.head; e != nil; e = e.next {
+		c.PushBack(e.Value)
+	}
+	return c
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// Complexity is O(n)
+func (r *ForwardList[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the list satisfies f. Complexity is O(n)
+func (r *ForwardList[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the list from front to back
+func (r *ForwardList[T]) ToSlice() []T {
+	s := make([]T, 0, r.Count())
+	for e := r.head; e != nil; e = e.next {
+		s = append(s, e.Value)
+	}
+	return s
+}
+
+// Return an iterator over the values of the list from front to back. As elements don't link back,
+// there is no Backward iterator.
+func (r *ForwardList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := r.head; e != nil; e = e.next {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and values of the list from front to back
+func (r *ForwardList[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := r.head; e != nil; e = e.next {
+			if !yield(i, e.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
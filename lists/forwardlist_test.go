@@ -0,0 +1,182 @@
+package lists
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestForwardList(t *testing.T) {
+	list := NewForwardList[int]()
+
+	// test if empty
+	if !list.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", list.IsEmpty(), true)
+	}
+
+	// pop from an empty list
+	_, err := list.PopFront()
+	if err == nil {
+		t.Errorf("PopFront() = %v, want %v", err, "empty list")
+	}
+
+	three := list.PushFront(3)
+	one := list.PushFront(1)
+	list.InsertAfter(2, one)
+	list.PushBack(5)
+	list.InsertAfter(4, three)
+
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 2, 3, 4, 5})
+	}
+
+	// check count method
+	if list.Count() != 5 {
+		t.Errorf("Count() = %v, want %v", list.Count(), 5)
+	}
+
+	// erase the last element and check the tail follows
+	if v := list.EraseAfter(list.Front().Next().Next().Next()); v != 5 {
+		t.Errorf("EraseAfter() = %v, want %v", v, 5)
+	}
+	if list.Back().Value != 4 {
+		t.Errorf("Back() = %v, want %v", list.Back().Value, 4)
+	}
+	list.PushBack(6)
+
+	element, err := list.PopFront()
+	if element != 1 || err != nil {
+		t.Errorf("PopFront() = %v, %v, want %v, %v", element, err, 1, nil)
+	}
+
+	list.Reverse()
+	if s := list.ToSlice(); !slices.Equal(s, []int{6, 4, 3, 2}) {
+		t.Errorf("Reverse() = %v, want %v", s, []int{6, 4, 3, 2})
+	}
+	if list.Back().Value != 2 {
+		t.Errorf("Back() = %v, want %v", list.Back().Value, 2)
+	}
+}
+
+func TestForwardListSplice(t *testing.T) {
+	list := NewForwardList[int]()
+	other := NewForwardList[int]()
+	for i := 1; i <= 3; i++ {
+		list.PushBack(i)
+	}
+	for i := 10; i <= 50; i += 10 {
+		other.PushBack(i)
+	}
+
+	// move 20, 30 after 1
+	first := other.Front()
+	last := first.Next().Next()
+	list.SpliceRangeAfter(list.Front(), other, first, last)
+
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 20, 30, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 20, 30, 2, 3})
+	}
+	if s := other.ToSlice(); !slices.Equal(s, []int{10, 40, 50}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{10, 40, 50})
+	}
+	if list.Count() != 5 || other.Count() != 3 {
+		t.Errorf("Count() = %v, %v, want %v, %v", list.Count(), other.Count(), 5, 3)
+	}
+
+	// move the tail of other to the front of list
+	list.SpliceRangeAfter(nil, other, other.Front(), other.Back())
+	if s := list.ToSlice(); !slices.Equal(s, []int{40, 50, 1, 20, 30, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{40, 50, 1, 20, 30, 2, 3})
+	}
+	if other.Back().Value != 10 {
+		t.Errorf("Back() = %v, want %v", other.Back().Value, 10)
+	}
+
+	// move everything that is left to the back
+	list.SpliceAfter(list.Back(), other)
+	if s := list.ToSlice(); !slices.Equal(s, []int{40, 50, 1, 20, 30, 2, 3, 10}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{40, 50, 1, 20, 30, 2, 3, 10})
+	}
+	if !other.IsEmpty() || other.Count() != 0 || list.Count() != 8 {
+		t.Errorf("Count() = %v, %v, want %v, %v", list.Count(), other.Count(), 8, 0)
+	}
+	list.PushBack(11)
+	if list.Back().Value != 11 {
+		t.Errorf("Back() = %v, want %v", list.Back().Value, 11)
+	}
+}
+
+func TestForwardListMerge(t *testing.T) {
+	list := NewForwardList[int]()
+	other := NewForwardList[int]()
+	for _, v := range []int{1, 4, 6, 9} {
+		list.PushBack(v)
+	}
+	for _, v := range []int{2, 3, 6, 10, 12} {
+		other.PushBack(v)
+	}
+
+	list.Merge(other, cmp.Less[int])
+
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 2, 3, 4, 6, 6, 9, 10, 12}) {
+		t.Errorf("Merge() = %v, want %v", s, []int{1, 2, 3, 4, 6, 6, 9, 10, 12})
+	}
+	if list.Count() != 9 || !other.IsEmpty() {
+		t.Errorf("Count() = %v, %v, want %v, %v", list.Count(), other.Count(), 9, 0)
+	}
+	if list.Back().Value != 12 {
+		t.Errorf("Back() = %v, want %v", list.Back().Value, 12)
+	}
+
+	// merging into an empty list
+	empty := NewForwardList[int]()
+	empty.Merge(list, cmp.Less[int])
+	if empty.Count() != 9 || empty.Back().Value != 12 {
+		t.Errorf("Count() = %v, want %v", empty.Count(), 9)
+	}
+}
+
+func TestForwardListClearClone(t *testing.T) {
+	list := NewForwardList[int]()
+	list.PushBack(1)
+	list.PushBack(2)
+
+	clone := list.Clone()
+	clone.PushBack(3)
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 2}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 2})
+	}
+	if s := clone.ToSlice(); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("clone ToSlice() = %v, want %v", s, []int{1, 2, 3})
+	}
+
+	list.Clear()
+	if !list.IsEmpty() || list.Count() != 0 || list.Back() != nil {
+		t.Errorf("Count() after Clear() = %v, want %v", list.Count(), 0)
+	}
+	list.PushBack(4)
+	if s := list.ToSlice(); !slices.Equal(s, []int{4}) {
+		t.Errorf("ToSlice() after Clear() = %v, want %v", s, []int{4})
+	}
+}
+
+func BenchmarkForwardListPushFront(b *testing.B) {
+	list := NewForwardList[int]()
+
+	for i := 0; i < b.N; i++ {
+		list.PushFront(i)
+	}
+}
+
+func BenchmarkForwardListPopFront(b *testing.B) {
+	list := NewForwardList[int]()
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		list.PushFront(i)
+	}
+
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		list.PopFront()
+	}
+}
@@ -0,0 +1,348 @@
+package lists
+
+import (
+	"cmp"
+	"iter"
+)
+
+// The number of children of every node of an IndexedHeap unless WithArity is used
+const DefaultHeapArity int = 4
+
+// Struct holding the settings of an IndexedHeap
+type heapConfig struct {
+	arity int
+}
+
+// HeapOption configures an IndexedHeap when passed to its constructor
+type HeapOption func(*heapConfig)
+
+// Set the number of children of every node of the heap. A higher arity makes the tree shallower, which speeds
+// up Push and DecreaseKey at the cost of more comparisons in Pop. Values below 2 select DefaultHeapArity
+func WithArity(d int) HeapOption {
+	return func(c *heapConfig) {
+		if d >= 2 {
+			c.arity = d
+		}
+	}
+}
+
+// A hidden function which applies opts over the default settings
+func newHeapConfig(opts []HeapOption) heapConfig {
+	c := heapConfig{arity: DefaultHeapArity}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Interface mapping the keys of an IndexedHeap to their positions in the heap
+type heapIndex[K comparable] interface {
+	get(key K) (int, bool)
+	set(key K, i int)
+	check(key K, op string) error
+	del(key K)
+	reset()
+}
+
+// A heapIndex for any comparable key, backed by a map
+type mapIndex[K comparable] map[K]int
+
+func (m mapIndex[K]) get(key K) (int, bool) {
+	i, ok := m[key]
+	return i, ok
+}
+
+func (m mapIndex[K]) set(key K, i int)             { m[key] = i }
+func (m mapIndex[K]) check(key K, op string) error { return nil }
+func (m mapIndex[K]) del(key K)                    { delete(m, key) }
+func (m mapIndex[K]) reset()                       { clear(m) }
+
+// A heapIndex for small non-negative integer keys, backed by a slice holding position+1 for every key
+// in the heap and 0 for every other key
+type denseIndex struct {
+	positions []int
+}
+
+func (d *denseIndex) get(key int) (int, bool) {
+	if key < 0 || key >= len(d.positions) || d.positions[key] == 0 {
+		return 0, false
+	}
+	return d.positions[key] - 1, true
+}
+
+func (d *denseIndex) set(key, i int) {
+	if key >= len(d.positions) {
+		d.positions = append(d.positions, make([]int, key+1-len(d.positions))...)
+	}
+	d.positions[key] = i + 1
+}
+
+// Larger keys grow the slice, so only negative keys are out of range
+func (d *denseIndex) check(key int, op string) error {
+	if key < 0 {
+		return newContainerError("IndexedHeap", op, ErrOutOfRange)
+	}
+	return nil
+}
+
+func (d *denseIndex) del(key int) {
+	if key >= 0 && key < len(d.positions) {
+		d.positions[key] = 0
+	}
+}
+
+func (d *denseIndex) reset() {
+	clear(d.positions)
+}
+
+// The IndexedHeap is a d-ary min-heap of keys of type K ordered by priorities of type P. Every key is in the
+// heap at most once and can be addressed directly, so its priority can be lowered with DecreaseKey, or the key
+// removed, in O(log n) without searching for it. This is the priority queue shortest path algorithms such as
+// Dijkstra's rely on.
+//
+// The key with the lowest priority according to less is at the top of the heap.
+type IndexedHeap[K comparable, P any] struct {
+	keys       []K
+	priorities []P
+	index      heapIndex[K] // position of every key in keys and priorities
+	less       func(a, b P) bool
+	arity      int
+}
+
+// The constructor for a new IndexedHeap instance with keys of type K and priorities of type P, where
+// less(a, b) reports whether priority a comes before priority b. WithArity changes the number of children
+// of every node.
+//
+// Returns a pointer to an IndexedHeap
+func NewIndexedHeap[K comparable, P any](less func(a, b P) bool, opts ...HeapOption) *IndexedHeap[K, P] {
+	return newIndexedHeap[K](make(mapIndex[K]), less, opts)
+}
+
+// A hidden function which returns an empty heap tracking the positions of its keys in index
+func newIndexedHeap[K comparable, P any](index heapIndex[K], less func(a, b P) bool, opts []HeapOption) *IndexedHeap[K, P] {
+	config := newHeapConfig(opts)
+	return &IndexedHeap[K, P]{
+		keys:       make([]K, 0),
+		priorities: make([]P, 0),
+		index:      index,
+		less:       less,
+		arity:      config.arity,
+	}
+}
+
+// The constructor for a new IndexedHeap instance with keys of type K and ordered priorities of type P,
+// which keeps the key with the smallest priority at the top.
+//
+// Returns a pointer to an IndexedHeap
+func NewOrderedIndexedHeap[K comparable, P cmp.Ordered](opts ...HeapOption) *IndexedHeap[K, P] {
+	return NewIndexedHeap[K](cmp.Less[P], opts...)
+}
+
+// The constructor for a new IndexedHeap instance with integer keys, such as the node numbers of a graph,
+// and priorities of type P. Keys are tracked in a slice instead of a map, which is faster but takes memory
+// proportional to the largest key, so keys must be small non-negative integers. Push refuses negative keys.
+// n is the expected number of keys, larger keys grow the slice. The slice never shrinks, so a single huge
+// key costs memory for every key below it until the heap is dropped; use NewIndexedHeap for sparse keys.
+//
+// Returns a pointer to an IndexedHeap
+func NewDenseIndexedHeap[P any](n int, less func(a, b P) bool, opts ...HeapOption) *IndexedHeap[int, P] {
+	return newIndexedHeap[int](&denseIndex{positions: make([]int, max(n, 0))}, less, opts)
+}
+
+// A hidden method which stores key and priority at position i
+func (r *IndexedHeap[K, P]) place(i int, key K, priority P) {
+	r.keys[i] = key
+	r.priorities[i] = priority
+	r.index.set(key, i)
+}
+
+// A hidden method which moves the entry at position i up until its parent comes before it
+func (r *IndexedHeap[K, P]) up(i int) {
+	key, priority := r.keys[i], r.priorities[i]
+	for i > 0 {
+		parent := (i - 1) / r.arity
+		if !r.less(priority, r.priorities[parent]) {
+			break
+		}
+		r.place(i, r.keys[parent], r.priorities[parent])
+		i = parent
+	}
+	r.place(i, key, priority)
+}
+
+// A hidden method which moves the entry at position i down until it comes before its children
+//
+// Returns true if the entry moved
+func (r *IndexedHeap[K, P]) down(i int) bool {
+	start := i
+	n := len(r.keys)
+	key, priority := r.keys[i], r.priorities[i]
+	for {
+		first := r.arity*i + 1
+		if first >= n {
+			break
+		}
+
+		best := first
+		for c := first + 1; c < min(first+r.arity, n); c++ {
+			if r.less(r.priorities[c], r.priorities[best]) {
+				best = c
+			}
+		}
+		if !r.less(r.priorities[best], priority) {
+			break
+		}
+		r.place(i, r.keys[best], r.priorities[best])
+		i = best
+	}
+	r.place(i, key, priority)
+	return i > start
+}
+
+// A hidden method which removes the entry at position i and returns it
+func (r *IndexedHeap[K, P]) remove(i int) (K, P) {
+	key, priority := r.keys[i], r.priorities[i]
+	r.index.del(key)
+
+	last := len(r.keys) - 1
+	if i != last {
+		r.place(i, r.keys[last], r.priorities[last])
+	}
+
+	var zeroKey K
+	var zeroPriority P
+	r.keys[last] = zeroKey // don't keep the key reachable
+	r.priorities[last] = zeroPriority
+	r.keys = r.keys[:last]
+	r.priorities = r.priorities[:last]
+
+	if i != last && !r.down(i) {
+		r.up(i)
+	}
+	return key, priority
+}
+
+// Return the number of keys in the heap
+func (r *IndexedHeap[K, P]) Count() uint {
+	return uint(len(r.keys))
+}
+
+// Checks if the heap is empty
+//
+// Return true if empty false otherwise
+func (r *IndexedHeap[K, P]) IsEmpty() bool {
+	return len(r.keys) == 0
+}
+
+// Report whether key is in the heap. Complexity is O(1)
+func (r *IndexedHeap[K, P]) Contains(key K) bool {
+	_, ok := r.index.get(key)
+	return ok
+}
+
+// Return the priority of key. Complexity is O(1)
+func (r *IndexedHeap[K, P]) Priority(key K) (P, error) {
+	i, ok := r.index.get(key)
+	if !ok {
+		var result P
+		return result, newContainerError("IndexedHeap", "Priority", ErrNotFound)
+	}
+	return r.priorities[i], nil
+}
+
+// Add key to the heap with the given priority. If key is already in the heap its priority is replaced,
+// whether that moves it up or down. Complexity is O(log n)
+//
+// Returns an error if the heap was built by NewDenseIndexedHeap and key is negative
+func (r *IndexedHeap[K, P]) Push(key K, priority P) error {
+	if err := r.index.check(key, "Push"); err != nil {
+		return err
+	}
+
+	if i, ok := r.index.get(key); ok {
+		r.priorities[i] = priority
+		if !r.down(i) {
+			r.up(i)
+		}
+		return nil
+	}
+
+	r.keys = append(r.keys, key)
+	r.priorities = append(r.priorities, priority)
+	r.up(len(r.keys) - 1)
+	return nil
+}
+
+// Lower the priority of key to the given priority. If priority does not come before the current priority
+// of key the heap is not modified. Complexity is O(log n)
+//
+// Returns an error if key is not in the heap
+func (r *IndexedHeap[K, P]) DecreaseKey(key K, priority P) error {
+	i, ok := r.index.get(key)
+	if !ok {
+		return newContainerError("IndexedHeap", "DecreaseKey", ErrNotFound)
+	}
+
+	if r.less(priority, r.priorities[i]) {
+		r.priorities[i] = priority
+		r.up(i)
+	}
+	return nil
+}
+
+// Remove key from the heap and return its priority. Complexity is O(log n)
+func (r *IndexedHeap[K, P]) Remove(key K) (P, error) {
+	i, ok := r.index.get(key)
+	if !ok {
+		var result P
+		return result, newContainerError("IndexedHeap", "Remove", ErrNotFound)
+	}
+
+	_, priority := r.remove(i)
+	return priority, nil
+}
+
+// Remove and return the key with the lowest priority together with that priority. Complexity is O(log n)
+func (r *IndexedHeap[K, P]) Pop() (K, P, error) {
+	if len(r.keys) == 0 {
+		var key K
+		var priority P
+		return key, priority, newContainerError("IndexedHeap", "Pop", ErrEmpty)
+	}
+
+	key, priority := r.remove(0)
+	return key, priority, nil
+}
+
+// Return the key with the lowest priority together with that priority without removing it. Complexity is O(1)
+func (r *IndexedHeap[K, P]) Peek() (K, P, error) {
+	if len(r.keys) == 0 {
+		var key K
+		var priority P
+		return key, priority, newContainerError("IndexedHeap", "Peek", ErrEmpty)
+	}
+
+	return r.keys[0], r.priorities[0], nil
+}
+
+// Remove all keys from the heap. The storage is kept, so a cleared heap can be refilled without
+// allocating. Complexity is O(n)
+func (r *IndexedHeap[K, P]) Clear() {
+	clear(r.keys)
+	clear(r.priorities)
+	r.index.reset()
+	r.keys = r.keys[:0]
+	r.priorities = r.priorities[:0]
+}
+
+// Return an iterator over the keys of the heap and their priorities in heap order, which is not sorted
+// except for the first key having the lowest priority
+func (r *IndexedHeap[K, P]) All() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		for i, key := range r.keys {
+			if !yield(key, r.priorities[i]) {
+				return
+			}
+		}
+	}
+}
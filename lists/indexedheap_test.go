@@ -0,0 +1,347 @@
+package lists
+
+import (
+	"cmp"
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Check that every entry comes after its parent and the index matches the positions
+func checkIndexedHeap[K comparable, P any](t *testing.T, h *IndexedHeap[K, P]) {
+	t.Helper()
+
+	count := 0
+	switch index := any(h.index).(type) {
+	case mapIndex[K]:
+		count = len(index)
+	case *denseIndex:
+		for _, p := range index.positions {
+			if p != 0 {
+				count++
+			}
+		}
+	}
+	if count != len(h.keys) {
+		t.Fatalf("index holds %v keys, want %v", count, len(h.keys))
+	}
+	for i, key := range h.keys {
+		if pos, _ := h.index.get(key); pos != i {
+			t.Fatalf("index of %v = %v, want %v", key, pos, i)
+		}
+		if parent := (i - 1) / h.arity; i > 0 && h.less(h.priorities[i], h.priorities[parent]) {
+			t.Fatalf("entry %v comes before its parent %v", i, parent)
+		}
+	}
+}
+
+func TestIndexedHeap(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		h := NewOrderedIndexedHeap[string, int](WithArity(d))
+
+		if _, _, err := h.Pop(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("d=%v Pop() on an empty heap error = %v, want %v", d, err, ErrEmpty)
+		}
+
+		for i, p := range rand.Perm(50) {
+			h.Push(fmt.Sprint("k", i), p)
+		}
+		checkIndexedHeap(t, h)
+
+		if !h.Contains("k7") || h.Contains("k50") {
+			t.Errorf("d=%v Contains() = %v, %v, want %v, %v", d, h.Contains("k7"), h.Contains("k50"), true, false)
+		}
+
+		// decreasing moves a key up, a higher priority is ignored
+		h.DecreaseKey("k7", -1)
+		h.DecreaseKey("k8", 1000)
+		checkIndexedHeap(t, h)
+		if key, priority, _ := h.Peek(); key != "k7" || priority != -1 {
+			t.Errorf("d=%v Peek() = %v, %v, want %v, %v", d, key, priority, "k7", -1)
+		}
+		if err := h.DecreaseKey("k50", 0); !errors.Is(err, ErrNotFound) {
+			t.Errorf("d=%v DecreaseKey() of a missing key error = %v, want %v", d, err, ErrNotFound)
+		}
+
+		// pushing an existing key replaces its priority in either direction
+		h.Push("k7", 100)
+		h.Push("k9", -2)
+		checkIndexedHeap(t, h)
+		if priority, _ := h.Priority("k7"); priority != 100 {
+			t.Errorf("d=%v Priority() = %v, want %v", d, priority, 100)
+		}
+
+		removed, _ := h.Remove("k9")
+		if removed != -2 || h.Contains("k9") {
+			t.Errorf("d=%v Remove() = %v, want %v", d, removed, -2)
+		}
+		if _, err := h.Remove("k9"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("d=%v Remove() of a missing key error = %v, want %v", d, err, ErrNotFound)
+		}
+		checkIndexedHeap(t, h)
+
+		priorities := make([]int, 0)
+		for !h.IsEmpty() {
+			_, priority, _ := h.Pop()
+			priorities = append(priorities, priority)
+		}
+		if len(priorities) != 49 || !slices.IsSorted(priorities) {
+			t.Errorf("d=%v Pop() returned %v priorities sorted = %v, want %v, %v", d, len(priorities), slices.IsSorted(priorities), 49, true)
+		}
+	}
+}
+
+func TestDenseIndexedHeapNegativeKey(t *testing.T) {
+	h := NewDenseIndexedHeap(4, cmp.Less[int])
+	if err := h.Push(-1, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Push() of a negative key error = %v, want %v", err, ErrOutOfRange)
+	}
+	if h.Count() != 0 || h.Contains(-1) {
+		t.Errorf("Count(), Contains() = %v, %v, want %v, %v", h.Count(), h.Contains(-1), 0, false)
+	}
+
+	// keys beyond the expected number grow the index, and a map backed heap takes any key
+	if err := h.Push(10, 0); err != nil || !h.Contains(10) {
+		t.Errorf("Push() of a large key error = %v, want %v", err, nil)
+	}
+	if err := NewOrderedIndexedHeap[int, int]().Push(-1, 0); err != nil {
+		t.Errorf("Push() of a negative key to a map backed heap error = %v, want %v", err, nil)
+	}
+}
+
+func TestIndexedHeapRandomized(t *testing.T) {
+	heaps := map[string]*IndexedHeap[int, int]{
+		"map":   NewOrderedIndexedHeap[int, int](WithArity(3)),
+		"dense": NewDenseIndexedHeap(10, cmp.Less[int], WithArity(3)),
+	}
+
+	for name, h := range heaps {
+		t.Run(name, func(t *testing.T) {
+			testIndexedHeapRandomized(t, h)
+		})
+	}
+}
+
+// Run random operations on h and compare the outcome with a map of priorities
+func testIndexedHeapRandomized(t *testing.T, h *IndexedHeap[int, int]) {
+	ref := make(map[int]int)
+	rng := rand.New(rand.NewSource(1))
+
+	for step := 0; step < 5000; step++ {
+		key := rng.Intn(200)
+		switch rng.Intn(4) {
+		case 0:
+			p := rng.Intn(1000)
+			h.Push(key, p)
+			ref[key] = p
+		case 1:
+			p := rng.Intn(1000)
+			h.DecreaseKey(key, p)
+			if old, ok := ref[key]; ok && p < old {
+				ref[key] = p
+			}
+		case 2:
+			h.Remove(key)
+			delete(ref, key)
+		case 3:
+			if key, priority, err := h.Pop(); err == nil {
+				if ref[key] != priority {
+					t.Fatalf("Pop() = %v, %v, want priority %v", key, priority, ref[key])
+				}
+				for k, p := range ref {
+					if p < priority {
+						t.Fatalf("Pop() = %v, but %v has priority %v", priority, k, p)
+					}
+				}
+				delete(ref, key)
+			}
+		}
+	}
+
+	checkIndexedHeap(t, h)
+	if int(h.Count()) != len(ref) {
+		t.Errorf("Count() = %v, want %v", h.Count(), len(ref))
+	}
+	for k, p := range h.All() {
+		if ref[k] != p {
+			t.Errorf("priority of %v = %v, want %v", k, p, ref[k])
+		}
+	}
+
+	h.Clear()
+	if !h.IsEmpty() || h.Contains(0) || h.Contains(-1) {
+		t.Errorf("IsEmpty() after Clear() = %v, want %v", h.IsEmpty(), true)
+	}
+}
+
+// A weighted directed graph as adjacency lists
+type graph [][]struct{ to, weight int }
+
+// Return a random graph of n nodes with degree outgoing edges per node, and a path through all nodes so
+// every node is reachable from node 0
+func randomGraph(n, degree int) graph {
+	rng := rand.New(rand.NewSource(42))
+	g := make(graph, n)
+	for v := range g {
+		if v+1 < n {
+			g[v] = append(g[v], struct{ to, weight int }{v + 1, 1 + rng.Intn(100)})
+		}
+		for i := 1; i < degree; i++ {
+			g[v] = append(g[v], struct{ to, weight int }{rng.Intn(n), 1 + rng.Intn(100)})
+		}
+	}
+	return g
+}
+
+// Dijkstra's shortest paths from node 0 using an IndexedHeap with DecreaseKey
+func dijkstraIndexedHeap(g graph, h *IndexedHeap[int, int]) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+
+	h.Push(0, 0)
+	for !h.IsEmpty() {
+		v, dv, _ := h.Pop()
+		dist[v] = dv
+		for _, e := range g[v] {
+			if dist[e.to] >= 0 {
+				continue
+			}
+			if h.Contains(e.to) {
+				h.DecreaseKey(e.to, dv+e.weight)
+			} else {
+				h.Push(e.to, dv+e.weight)
+			}
+		}
+	}
+	return dist
+}
+
+// An entry of the container/heap based priority queues
+type heapItem struct {
+	node, dist, index int
+}
+
+// A container/heap min-heap of entries ordered by dist which keeps each entry's index up to date
+type itemHeap []*heapItem
+
+func (h itemHeap) Len() int           { return len(h) }
+func (h itemHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h itemHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *itemHeap) Push(x any) {
+	item := x.(*heapItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+func (h *itemHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return item
+}
+
+// Dijkstra's shortest paths from node 0 using container/heap with heap.Fix as decrease-key
+func dijkstraContainerHeapFix(g graph) []int {
+	dist := make([]int, len(g))
+	items := make([]*heapItem, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+
+	h := &itemHeap{}
+	items[0] = &heapItem{node: 0}
+	heap.Push(h, items[0])
+	for h.Len() > 0 {
+		item := heap.Pop(h).(*heapItem)
+		dist[item.node] = item.dist
+		for _, e := range g[item.node] {
+			if dist[e.to] >= 0 {
+				continue
+			}
+			if next := items[e.to]; next == nil {
+				items[e.to] = &heapItem{node: e.to, dist: item.dist + e.weight}
+				heap.Push(h, items[e.to])
+			} else if item.dist+e.weight < next.dist {
+				next.dist = item.dist + e.weight
+				heap.Fix(h, next.index)
+			}
+		}
+	}
+	return dist
+}
+
+// Dijkstra's shortest paths from node 0 using container/heap with lazy deletion of stale entries
+func dijkstraContainerHeapLazy(g graph) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+
+	h := &itemHeap{{node: 0}}
+	for h.Len() > 0 {
+		item := heap.Pop(h).(*heapItem)
+		if dist[item.node] >= 0 {
+			continue
+		}
+		dist[item.node] = item.dist
+		for _, e := range g[item.node] {
+			if dist[e.to] < 0 {
+				heap.Push(h, &heapItem{node: e.to, dist: item.dist + e.weight})
+			}
+		}
+	}
+	return dist
+}
+
+func TestIndexedHeapDijkstra(t *testing.T) {
+	g := randomGraph(2000, 5)
+	want := dijkstraContainerHeapLazy(g)
+
+	for _, d := range []int{2, 4, 8} {
+		if dist := dijkstraIndexedHeap(g, NewOrderedIndexedHeap[int, int](WithArity(d))); !slices.Equal(dist, want) {
+			t.Errorf("d=%v distances differ from the container/heap result", d)
+		}
+		if dist := dijkstraIndexedHeap(g, NewDenseIndexedHeap(len(g), cmp.Less[int], WithArity(d))); !slices.Equal(dist, want) {
+			t.Errorf("dense d=%v distances differ from the container/heap result", d)
+		}
+	}
+	if dist := dijkstraContainerHeapFix(g); !slices.Equal(dist, want) {
+		t.Errorf("heap.Fix distances differ from the lazy deletion result")
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	g := randomGraph(100000, 8)
+
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("IndexedHeap-d%v", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dijkstraIndexedHeap(g, NewOrderedIndexedHeap[int, int](WithArity(d)))
+			}
+		})
+		b.Run(fmt.Sprintf("DenseIndexedHeap-d%v", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dijkstraIndexedHeap(g, NewDenseIndexedHeap(len(g), cmp.Less[int], WithArity(d)))
+			}
+		})
+	}
+	b.Run("ContainerHeapFix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dijkstraContainerHeapFix(g)
+		}
+	})
+	b.Run("ContainerHeapLazy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dijkstraContainerHeapLazy(g)
+		}
+	})
+}
@@ -0,0 +1,281 @@
+package lists
+
+import "iter"
+
+// Element is a handle to a single entry of a List. Holding on to an Element allows the entry
+// to be moved or removed in O(1) without searching for it.
+type Element[T any] struct {
+	next, prev *Element[T]
+	list       *List[T]
+
+	// The value stored in this element
+	Value T
+}
+
+// Return the next element of the list or nil if e is the last element
+func (e *Element[T]) Next() *Element[T] {
+	if p := e.next; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// Return the previous element of the list or nil if e is the first element
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// The List is a doubly linked list of elements of type T. Every insertion returns an Element
+// handle which can later be used to insert around, move or remove that entry in O(1).
+//
+// The zero value of a List is an empty list ready to use.
+type List[T any] struct {
+	root        Element[T] // sentinel; root.next is the front and root.prev is the back
+	curBuffSize uint
+}
+
+// The constructor for a new List instance with elements of type T.
+//
+// Returns a pointer to a List
+func NewList[T any]() *List[T] {
+	return new(List[T]).init()
+}
+
+// A hidden method which links the sentinel to itself
+func (r *List[T]) init() *List[T] {
+	r.root.next = &r.root
+	r.root.prev = &r.root
+	r.curBuffSize = 0
+	return r
+}
+
+// A hidden method which initializes a zero value List on first use
+func (r *List[T]) lazyInit() {
+	if r.root.next == nil {
+		r.init()
+	}
+}
+
+// A hidden method which links e after at and returns e
+func (r *List[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = r
+	r.curBuffSize++
+	return e
+}
+
+// A hidden method which wraps value in a new element and links it after at
+func (r *List[T]) insertValue(value T, at *Element[T]) *Element[T] {
+	return r.insert(&Element[T]{Value: value}, at)
+}
+
+// A hidden method which unlinks e from the list
+func (r *List[T]) remove(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil // avoid memory leaks
+	e.prev = nil // avoid memory leaks
+	e.list = nil
+	r.curBuffSize--
+}
+
+// A hidden method which relinks e after at
+func (r *List[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// Return the number of elements in the list
+func (r *List[T]) Count() uint {
+	return r.curBuffSize
+}
+
+// Checks if the list is empty
+//
+// Return true if empty false otherwise
+func (r *List[T]) IsEmpty() bool {
+	return r.curBuffSize == 0
+}
+
+// Return the first element of the list or nil if the list is empty
+func (r *List[T]) Front() *Element[T] {
+	if r.curBuffSize == 0 {
+		return nil
+	}
+	return r.root.next
+}
+
+// Return the last element of the list or nil if the list is empty
+func (r *List[T]) Back() *Element[T] {
+	if r.curBuffSize == 0 {
+		return nil
+	}
+	return r.root.prev
+}
+
+// Insert a new element with the given value at the front of the list and return it. Complexity is O(1)
+func (r *List[T]) PushFront(value T) *Element[T] {
+	r.lazyInit()
+	return r.insertValue(value, &r.root)
+}
+
+// Insert a new element with the given value at the back of the list and return it. Complexity is O(1)
+func (r *List[T]) PushBack(value T) *Element[T] {
+	r.lazyInit()
+	return r.insertValue(value, r.root.prev)
+}
+
+// Insert a new element with the given value immediately before mark and return it.
+// If mark is not an element of this list, the list is not modified and nil is returned. Complexity is O(1)
+func (r *List[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	if mark == nil || mark.list != r {
+		return nil
+	}
+	return r.insertValue(value, mark.prev)
+}
+
+// Insert a new element with the given value immediately after mark and return it.
+// If mark is not an element of this list, the list is not modified and nil is returned. Complexity is O(1)
+func (r *List[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	if mark == nil || mark.list != r {
+		return nil
+	}
+	return r.insertValue(value, mark)
+}
+
+// Remove e from the list if it is an element of this list and return its value. Complexity is O(1)
+func (r *List[T]) Remove(e *Element[T]) T {
+	if e.list == r {
+		r.remove(e)
+	}
+	return e.Value
+}
+
+// Move e to the front of the list. If e is not an element of this list, the list is not modified. Complexity is O(1)
+func (r *List[T]) MoveToFront(e *Element[T]) {
+	if e.list != r || r.root.next == e {
+		return
+	}
+	r.move(e, &r.root)
+}
+
+// Move e to the back of the list. If e is not an element of this list, the list is not modified. Complexity is O(1)
+func (r *List[T]) MoveToBack(e *Element[T]) {
+	if e.list != r || r.root.prev == e {
+		return
+	}
+	r.move(e, r.root.prev)
+}
+
+// Move e immediately before mark. If e or mark is not an element of this list, or e == mark,
+// the list is not modified. Complexity is O(1)
+func (r *List[T]) MoveBefore(e, mark *Element[T]) {
+	if e.list != r || mark.list != r || e == mark {
+		return
+	}
+	r.move(e, mark.prev)
+}
+
+// Move e immediately after mark. If e or mark is not an element of this list, or e == mark,
+// the list is not modified. Complexity is O(1)
+func (r *List[T]) MoveAfter(e, mark *Element[T]) {
+	if e.list != r || mark.list != r || e == mark {
+		return
+	}
+	r.move(e, mark)
+}
+
+// Remove all elements from the list. The removed elements are unlinked, so Element handles held elsewhere
+// no longer refer to the list. Complexity is O(n)
+func (r *List[T]) Clear() {
+	for e := r.Front(); e != nil; {
+		next := e.Next()
+		e.next = nil // avoid memory leaks
+		e.prev = nil // avoid memory leaks
+		e.list = nil
+		e = next
+	}
+	r.init()
+}
+
+// Return a copy of the list with new elements holding the same values. The values themselves are copied
+// by value. Complexity is O(n)
+func (r *List[T]) Clone() *List[T] {
+	c := NewList[T]()
+	for e := r.Front(); e != nil; e = e.Next() {
+		c.insertValue(e.Value, c.root.prev)
+	}
+	return c
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// Complexity is O(n)
+func (r *List[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the list satisfies f. Complexity is O(n)
+func (r *List[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the list from front to back
+func (r *List[T]) ToSlice() []T {
+	s := make([]T, 0, r.curBuffSize)
+	for e := r.Front(); e != nil; e = e.Next() {
+		s = append(s, e.Value)
+	}
+	return s
+}
+
+// Return an iterator over the values of the list from front to back. The loop body may remove
+// the element it is visiting
+func (r *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := r.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e.Value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Return an iterator over the values of the list from back to front
+func (r *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := r.Back(); e != nil; e = e.Prev() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and values of the list from front to back
+func (r *List[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := r.Front(); e != nil; e = e.Next() {
+			if !yield(i, e.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
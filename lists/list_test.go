@@ -0,0 +1,210 @@
+package lists
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	list := NewList[int]()
+
+	// test if empty
+	if !list.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", list.IsEmpty(), true)
+	}
+
+	// front and back of an empty list
+	if list.Front() != nil || list.Back() != nil {
+		t.Errorf("Front(), Back() = %v, %v, want %v, %v", list.Front(), list.Back(), nil, nil)
+	}
+
+	two := list.PushBack(2)
+	one := list.PushFront(1)
+	four := list.PushBack(4)
+	three := list.InsertBefore(3, four)
+	five := list.InsertAfter(5, four)
+
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 2, 3, 4, 5})
+	}
+
+	// check count method
+	if list.Count() != 5 {
+		t.Errorf("Count() = %v, want %v", list.Count(), 5)
+	}
+
+	// walk backwards
+	s := make([]int, 0)
+	for e := list.Back(); e != nil; e = e.Prev() {
+		s = append(s, e.Value)
+	}
+	if !slices.Equal(s, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("Prev() walk = %v, want %v", s, []int{5, 4, 3, 2, 1})
+	}
+
+	list.MoveToFront(five)
+	list.MoveToBack(one)
+	if s := list.ToSlice(); !slices.Equal(s, []int{5, 2, 3, 4, 1}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{5, 2, 3, 4, 1})
+	}
+
+	list.MoveAfter(two, four)
+	list.MoveBefore(three, five)
+	if s := list.ToSlice(); !slices.Equal(s, []int{3, 5, 4, 2, 1}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{3, 5, 4, 2, 1})
+	}
+
+	// remove an element
+	if v := list.Remove(four); v != 4 {
+		t.Errorf("Remove() = %v, want %v", v, 4)
+	}
+	if list.Count() != 4 {
+		t.Errorf("Count() = %v, want %v", list.Count(), 4)
+	}
+
+	// a removed element is no longer part of the list
+	list.Remove(four)
+	list.MoveToFront(four)
+	if list.InsertAfter(6, four) != nil {
+		t.Errorf("InsertAfter() on removed element, want %v", nil)
+	}
+	if s := list.ToSlice(); !slices.Equal(s, []int{3, 5, 2, 1}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{3, 5, 2, 1})
+	}
+
+	// elements of another list are rejected
+	other := NewList[int]()
+	foreign := other.PushBack(9)
+	list.Remove(foreign)
+	list.MoveToBack(foreign)
+	if list.Count() != 4 || other.Count() != 1 {
+		t.Errorf("Count() = %v, %v, want %v, %v", list.Count(), other.Count(), 4, 1)
+	}
+
+	for e := list.Front(); e != nil; e = list.Front() {
+		list.Remove(e)
+	}
+	if !list.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", list.IsEmpty(), true)
+	}
+}
+
+func TestListZeroValue(t *testing.T) {
+	var list List[string]
+
+	list.PushBack("b")
+	list.PushFront("a")
+
+	if s := list.ToSlice(); !slices.Equal(s, []string{"a", "b"}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []string{"a", "b"})
+	}
+}
+
+func TestListIterators(t *testing.T) {
+	list := NewList[int]()
+	for i := 0; i < 5; i++ {
+		list.PushBack(i)
+	}
+
+	if s := slices.Collect(list.Backward()); !slices.Equal(s, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("Backward() = %v, want %v", s, []int{4, 3, 2, 1, 0})
+	}
+	for i, v := range list.Indexed() {
+		if i != v {
+			t.Errorf("Indexed() = %v, %v, want %v, %v", i, v, i, i)
+		}
+	}
+
+	// removing the visited element while iterating
+	e := list.Front()
+	for v := range list.All() {
+		next := e.Next()
+		if v%2 == 0 {
+			list.Remove(e)
+		}
+		e = next
+	}
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 3}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 3})
+	}
+}
+
+func TestListClearClone(t *testing.T) {
+	list := NewList[int]()
+	e := list.PushBack(1)
+	list.PushBack(2)
+	list.PushBack(3)
+
+	clone := list.Clone()
+	clone.PushFront(0)
+	if s := list.ToSlice(); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 2, 3})
+	}
+	if s := clone.ToSlice(); !slices.Equal(s, []int{0, 1, 2, 3}) {
+		t.Errorf("clone ToSlice() = %v, want %v", s, []int{0, 1, 2, 3})
+	}
+
+	list.Clear()
+	if !list.IsEmpty() || list.Front() != nil {
+		t.Errorf("Count() after Clear() = %v, want %v", list.Count(), 0)
+	}
+
+	// handles of cleared elements no longer belong to the list
+	if e.Next() != nil || list.InsertAfter(5, e) != nil {
+		t.Errorf("element of a cleared list is still linked")
+	}
+	list.Remove(e)
+	if list.Count() != 0 {
+		t.Errorf("Count() after removing a cleared element = %v, want %v", list.Count(), 0)
+	}
+
+	safe := NewSafeList[int]()
+	safe.PushBack(1)
+	safeClone := safe.Clone()
+	safe.Clear()
+	if safe.Count() != 0 || safeClone.Count() != 1 {
+		t.Errorf("SafeList Count() = %v, clone Count() = %v, want %v, %v", safe.Count(), safeClone.Count(), 0, 1)
+	}
+}
+
+func TestListIndexFunc(t *testing.T) {
+	list := NewList[int]()
+	safe := NewSafeList[int]()
+	forward := NewForwardList[int]()
+	for i := 0; i < 5; i++ {
+		list.PushBack(i)
+		safe.PushBack(i)
+		forward.PushBack(i)
+	}
+
+	even := func(x int) bool { return x > 0 && x%2 == 0 }
+	negative := func(x int) bool { return x < 0 }
+
+	if i, j, k := list.IndexFunc(even), safe.IndexFunc(even), forward.IndexFunc(even); i != 2 || j != 2 || k != 2 {
+		t.Errorf("IndexFunc() = %v, %v, %v, want %v", i, j, k, 2)
+	}
+	if list.ContainsFunc(negative) || safe.ContainsFunc(negative) || forward.ContainsFunc(negative) {
+		t.Errorf("ContainsFunc() = %v, want %v", true, false)
+	}
+}
+
+func BenchmarkListPushBack(b *testing.B) {
+	list := NewList[int]()
+
+	for i := 0; i < b.N; i++ {
+		list.PushBack(i)
+	}
+}
+
+func BenchmarkListRemove(b *testing.B) {
+	list := NewList[int]()
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		list.PushBack(i)
+	}
+
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		list.Remove(list.Front())
+	}
+}
@@ -0,0 +1,286 @@
+package lists
+
+import (
+	"iter"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync/atomic"
+)
+
+// How many times a Push offered for elimination waits for a Pop to take it before withdrawing the offer
+const eliminationSpins = 16
+
+// Struct holding the settings of a LockFreeStack
+type lockFreeStackConfig struct {
+	eliminationSlots int
+}
+
+// LockFreeStackOption configures a LockFreeStack when passed to its constructor
+type LockFreeStackOption func(*lockFreeStackConfig)
+
+// Add an elimination array of n slots. When a Push or Pop loses the race for the top of the stack it meets
+// a Pop or Push in a random slot instead, and the two cancel out without touching the stack. This spreads
+// the load of many goroutines hammering the stack at once, at the cost of a little latency for a Push
+// which finds no partner. Values below 1 disable elimination, which is the default
+func WithElimination(n int) LockFreeStackOption {
+	return func(c *lockFreeStackConfig) {
+		c.eliminationSlots = max(n, 0)
+	}
+}
+
+// Struct holding an element of a LockFreeStack. Nodes are never modified once pushed, so readers may walk
+// them while other goroutines push and pop
+type lfNode[T any] struct {
+	next  *lfNode[T]
+	value T
+}
+
+// A LockFreeStack is a lock-free stack for any number of goroutines, the stack of Treiber. Push and Pop
+// swap the top of the stack with a single compare-and-swap. Every Push links a new node and popped nodes
+// are left to the garbage collector, so a node is never reused while another goroutine may still hold it,
+// which rules out the ABA problem.
+//
+// LockFreeStack is thread safe. However only the stack structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// LockFreeStack is a stack that implements the Lifo interface
+type LockFreeStack[T any] struct {
+	_           cacheLinePad
+	head        atomic.Pointer[lfNode[T]]
+	_           cacheLinePad
+	count       atomic.Int64
+	elimination []eliminationSlot[T]
+}
+
+// A slot of the elimination array, padded so neighbouring slots don't share a cache line
+type eliminationSlot[T any] struct {
+	offer atomic.Pointer[lfNode[T]] // a node waiting for a Pop, or nil
+	_     [cacheLineSize - 8]byte
+}
+
+// The constructor for a new LockFreeStack instance with elements of type T.
+// WithElimination adds an elimination array for high contention.
+//
+// Returns a pointer to a LockFreeStack
+func NewLockFreeStack[T any](opts ...LockFreeStackOption) *LockFreeStack[T] {
+	config := lockFreeStackConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &LockFreeStack[T]{
+		elimination: make([]eliminationSlot[T], config.eliminationSlots),
+	}
+}
+
+// A hidden method which links the chain from first to last on top of the stack.
+// With elimination enabled a single node may be taken by a Pop instead
+func (r *LockFreeStack[T]) push(first, last *lfNode[T], n int) {
+	for {
+		head := r.head.Load()
+		last.next = head
+		if r.head.CompareAndSwap(head, first) {
+			r.count.Add(int64(n))
+			return
+		}
+		if n == 1 && r.eliminate(first) {
+			return
+		}
+	}
+}
+
+// A hidden method which offers node in a random slot of the elimination array and waits a little for a Pop
+// to take it
+//
+// Returns true if a Pop took the node
+func (r *LockFreeStack[T]) eliminate(node *lfNode[T]) bool {
+	if len(r.elimination) == 0 {
+		return false
+	}
+
+	slot := &r.elimination[rand.IntN(len(r.elimination))]
+	if !slot.offer.CompareAndSwap(nil, node) {
+		return false
+	}
+	for i := 0; i < eliminationSpins; i++ {
+		if slot.offer.Load() != node {
+			return true
+		}
+		runtime.Gosched()
+	}
+	// withdraw the offer, unless a Pop took it in the meantime
+	return !slot.offer.CompareAndSwap(node, nil)
+}
+
+// A hidden method which takes a node offered by a Push in a random slot of the elimination array
+//
+// Returns nil if there was none
+func (r *LockFreeStack[T]) collide() *lfNode[T] {
+	if len(r.elimination) == 0 {
+		return nil
+	}
+
+	slot := &r.elimination[rand.IntN(len(r.elimination))]
+	if node := slot.offer.Load(); node != nil && slot.offer.CompareAndSwap(node, nil) {
+		return node
+	}
+	return nil
+}
+
+// Return the number of elements in the stack. While other goroutines are active the count may be
+// outdated as soon as it is returned
+func (r *LockFreeStack[T]) Count() uint {
+	return uint(max(r.count.Load(), 0))
+}
+
+// Checks if the stack is empty
+//
+// Return true if empty false otherwise
+func (r *LockFreeStack[T]) IsEmpty() bool {
+	return r.head.Load() == nil
+}
+
+// Pushes a new element T onto the stack. Complexity is O(1)
+func (r *LockFreeStack[T]) Push(element T) {
+	node := &lfNode[T]{value: element}
+	r.push(node, node, 1)
+}
+
+// Pushes elements of type T onto the stack in the given order, so the last one ends up on top.
+// The elements are linked together first and appear on the stack at once, so elements of other
+// goroutines never end up in between. Complexity is O(k)
+func (r *LockFreeStack[T]) PushAll(elements ...T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	// nodes are allocated one by one so a popped node doesn't keep the rest of the batch reachable
+	last := &lfNode[T]{value: elements[0]}
+	first := last
+	for _, element := range elements[1:] {
+		first = &lfNode[T]{next: first, value: element}
+	}
+	r.push(first, last, len(elements))
+}
+
+// Pops an element T from the top of the stack. Complexity is O(1)
+//
+// Returns an error if the stack is empty
+func (r *LockFreeStack[T]) Pop() (T, error) {
+	for {
+		head := r.head.Load()
+		if head == nil {
+			var result T
+			return result, newContainerError("LockFreeStack", "Pop", ErrEmpty)
+		}
+		if r.head.CompareAndSwap(head, head.next) {
+			r.count.Add(-1)
+			return head.value, nil
+		}
+		if node := r.collide(); node != nil {
+			return node.value, nil
+		}
+	}
+}
+
+// Pop up to len(dst) elements into dst in Pop order. The elements are unlinked from the stack at once,
+// so they are the topmost elements at one point in time. Complexity is O(k)
+//
+// Returns the number of elements popped
+func (r *LockFreeStack[T]) PopInto(dst []T) int {
+	if len(dst) == 0 {
+		return 0
+	}
+
+	for {
+		head := r.head.Load()
+		rest, n := head, 0
+		for rest != nil && n < len(dst) {
+			rest = rest.next
+			n++
+		}
+		if head == rest || r.head.CompareAndSwap(head, rest) {
+			r.count.Add(-int64(n))
+			for node, i := head, 0; i < n; node, i = node.next, i+1 {
+				dst[i] = node.value
+			}
+			return n
+		}
+	}
+}
+
+// Pop up to n elements and return them in Pop order. Complexity is O(n)
+func (r *LockFreeStack[T]) PopN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.Count())))
+	k := r.PopInto(s)
+	return s[:k]
+}
+
+// Return an iterator which pops elements until the stack is empty.
+// Breaking out of the loop leaves the remaining elements on the stack
+func (r *LockFreeStack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Pop()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Peeks at the top element of the stack without removing it. Complexity is O(1)
+//
+// Returns an error if the stack is empty
+func (r *LockFreeStack[T]) Peek() (T, error) {
+	head := r.head.Load()
+	if head == nil {
+		var result T
+		return result, newContainerError("LockFreeStack", "Peek", ErrEmpty)
+	}
+	return head.value, nil
+}
+
+// Return a slice representation of the stack in Pop order. The slice is a snapshot of the stack at one
+// point in time. Complexity is O(n)
+func (r *LockFreeStack[T]) ToSlice() []T {
+	return slices.Collect(r.All())
+}
+
+// Return an iterator over the elements of the stack from top to bottom, in Pop order. Nodes never change
+// once pushed, so the iterator walks a snapshot of the stack taken when the loop starts
+func (r *LockFreeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := r.head.Load(); node != nil; node = node.next {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the stack from bottom to top. The stack is only linked from
+// the top, so the snapshot is collected first. Complexity is O(n)
+func (r *LockFreeStack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range slices.Backward(r.ToSlice()) {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the stack from top to bottom
+func (r *LockFreeStack[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for node := r.head.Load(); node != nil; node = node.next {
+			if !yield(i, node.value) {
+				return
+			}
+			i++
+		}
+	}
+}
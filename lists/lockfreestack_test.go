@@ -0,0 +1,103 @@
+package lists
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeStackConcurrent(t *testing.T) {
+	const goroutines, n = 8, 5000
+
+	stacks := map[string]*LockFreeStack[int]{
+		"LockFreeStack":            NewLockFreeStack[int](),
+		"LockFreeStackElimination": NewLockFreeStack[int](WithElimination(2)),
+	}
+
+	for name, stack := range stacks {
+		// every goroutine pushes its own elements and pops as many, whichever they are
+		popped := make([][]int, goroutines)
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < n; i++ {
+					stack.Push(g*n + i)
+					stack.Peek()
+					if element, err := stack.Pop(); err == nil {
+						popped[g] = append(popped[g], element)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		count := make([]int, goroutines*n)
+		for _, s := range popped {
+			for _, element := range s {
+				count[element]++
+			}
+		}
+		for element := range stack.Drain() {
+			count[element]++
+		}
+		for element, k := range count {
+			if k != 1 {
+				t.Fatalf("%v element %v popped %v times, want %v", name, element, k, 1)
+			}
+		}
+		if stack.Count() != 0 {
+			t.Errorf("%v Count() = %v, want %v", name, stack.Count(), 0)
+		}
+	}
+}
+
+func TestLockFreeStackPushAllConcurrent(t *testing.T) {
+	const goroutines, batches = 4, 1000
+	stack := NewLockFreeStack[int](WithElimination(4))
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for b := 0; b < batches; b++ {
+				first := (g*batches + b) * 3
+				stack.PushAll(first, first+1, first+2)
+			}
+		}(g)
+	}
+
+	// snapshots taken meanwhile never split a batch
+	for k := 0; k < 100; k++ {
+		checkBatches(t, stack.ToSlice())
+	}
+	wg.Wait()
+
+	s := stack.ToSlice()
+	checkBatches(t, s)
+	if len(s) != goroutines*batches*3 || stack.Count() != uint(len(s)) {
+		t.Errorf("ToSlice() has %v elements and Count() = %v, want %v", len(s), stack.Count(), goroutines*batches*3)
+	}
+
+	// PopInto takes the topmost elements at once
+	dst := make([]int, 3)
+	if n := stack.PopInto(dst); n != 3 || !slices.Equal(dst, s[:3]) {
+		t.Errorf("PopInto() = %v, %v, want %v, %v", n, dst, 3, s[:3])
+	}
+}
+
+// Check that s holds whole batches of three consecutive elements pushed with PushAll
+func checkBatches(t *testing.T, s []int) {
+	t.Helper()
+
+	if len(s)%3 != 0 {
+		t.Fatalf("snapshot has %v elements, want a multiple of %v", len(s), 3)
+	}
+	for i := 0; i < len(s); i += 3 {
+		if s[i]%3 != 2 || s[i+1] != s[i]-1 || s[i+2] != s[i]-2 {
+			t.Fatalf("snapshot holds %v, want a whole batch", s[i:i+3])
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package lists
 
-import "errors"
+import (
+	"iter"
+	"slices"
+)
 
 // The LSQueue is a collection of entities that are maintained in a sequence and can be modified
 // by the addition of entities at one end of the sequence and the removal of entities from the
 // other end of the sequence. This is a specialised version of queue that has a limited size.
-// When the limit is reached the first equeued element will be replaced by the next incoming enque.
+// When the limit is reached the first equeued element will be replaced by the next incoming enque,
+// unless a different OverflowPolicy was selected.
 //
 // LSQueue is a list that implements the Fifo interface
 type LSQueue[T any] struct {
@@ -13,18 +17,22 @@ type LSQueue[T any] struct {
 	curBuffSize uint
 	lastIndex   int
 	data        []T
+	config      lsQueueConfig[T]
 }
 
 // The constructor for a new LSQueue instance with elements of type T.
+// Options such as WithOverflowPolicy and WithEvictionCallback change what happens once the queue is full.
+// LSQueue never blocks, so the Block policy behaves like Reject.
 //
 // # Returns a pointer to a LSQueue
-func NewLSQueue[T any](size uint) Fifo[T] {
+func NewLSQueue[T any](size uint, opts ...LSQueueOption[T]) *LSQueue[T] {
 
 	return &LSQueue[T]{
 		maxBuffSize: size,
 		curBuffSize: 0,
 		lastIndex:   0,
 		data:        make([]T, size),
+		config:      newLSQueueConfig(opts),
 	}
 }
 
@@ -48,31 +56,114 @@ func (r *LSQueue[T]) Capacity() int {
 	return int(r.maxBuffSize)
 }
 
+// A hidden method which stores element if there is room for it or the policy is DropOldest
+//
+// Returns false if the element was not stored
+func (r *LSQueue[T]) offer(element T) bool {
+	if r.curBuffSize == r.maxBuffSize {
+		if r.config.policy != DropOldest || r.maxBuffSize == 0 {
+			return false
+		}
+		r.config.evict(r.data[r.getFrontElementIndex()])
+	} else {
+		r.curBuffSize++
+	}
+
+	r.lastIndex = (r.lastIndex + 1) % int(r.maxBuffSize)
+	r.data[r.lastIndex] = element
+	return true
+}
+
 // Add an element of type T to the end of the queue. Complexity is O(1)
+//
+// If the queue is full the overflow policy decides which element is discarded. A discarded element is
+// always passed to the eviction callback, use TryEnqueue to get a refused element back
 func (r *LSQueue[T]) Enqueue(element T) {
-	if r.maxBuffSize == 0 {
-		return
+	if !r.offer(element) {
+		r.config.evict(element)
 	}
+}
 
-	if r.curBuffSize+1 < r.maxBuffSize {
-		r.curBuffSize++
+// Add elements of type T to the end of the queue in the given order, applying the overflow policy
+// to each one. Complexity is O(k)
+func (r *LSQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order, applying the overflow policy
+// to each one. Complexity is O(k)
+func (r *LSQueue[T]) EnqueueSlice(elements []T) {
+	for _, element := range elements {
+		r.Enqueue(element)
 	}
+}
 
-	r.lastIndex = (r.lastIndex + 1) % int(r.maxBuffSize)
-	r.data[r.lastIndex] = element
+// Add an element of type T to the end of the queue. Complexity is O(1)
+//
+// Returns an error wrapping ErrFull if the queue is full and the element was not added. Under DropNewest
+// the element is passed to the eviction callback as well, under Reject and Block it is not
+func (r *LSQueue[T]) TryEnqueue(element T) error {
+	if !r.offer(element) {
+		if r.config.dropsRefused() {
+			r.config.evict(element)
+		}
+		return newContainerError("LSQueue", "TryEnqueue", ErrFull)
+	}
+	return nil
 }
 
 // Remove and return am element of type T from the beginning of the queue. Complexity is O(1)
 func (r *LSQueue[T]) Dequeue() (T, error) {
 	if r.curBuffSize == 0 {
 		var result T
-		return result, errors.New("empty list")
+		return result, newContainerError("LSQueue", "Dequeue", ErrEmpty)
 	}
 
 	indexOfElementToDequeue := r.getFrontElementIndex()
+	result := r.data[indexOfElementToDequeue]
+	var zero T
+	r.data[indexOfElementToDequeue] = zero // don't keep the value reachable
 
 	r.curBuffSize--
-	return r.data[indexOfElementToDequeue], nil
+	return result, nil
+}
+
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *LSQueue[T]) DequeueInto(dst []T) int {
+	n := min(len(dst), int(r.curBuffSize))
+	front := r.getFrontElementIndex()
+	var zero T
+
+	for i := 0; i < n; i++ {
+		j := (front + i) % int(r.maxBuffSize)
+		dst[i] = r.data[j]
+		r.data[j] = zero
+	}
+
+	r.curBuffSize -= uint(n)
+	return n
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order. Complexity is O(n)
+func (r *LSQueue[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.DequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue
+func (r *LSQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r.curBuffSize > 0 {
+			element, _ := r.Dequeue()
+			if !yield(element) {
+				return
+			}
+		}
+	}
 }
 
 // Checks if the queue is empty
@@ -85,7 +176,6 @@ func (r *LSQueue[T]) IsEmpty() bool {
 // Checks if the limited size queue is full
 //
 // Return true if the limites size queue has reached its given capacity. Otherwise returns false.
-// Also returns false if the queue was created with a size of 0 (generic unlimited queue)
 func (r *LSQueue[T]) IsFull() bool {
 	return r.curBuffSize == r.maxBuffSize
 }
@@ -94,13 +184,55 @@ func (r *LSQueue[T]) IsFull() bool {
 func (r *LSQueue[T]) Peek() (T, error) {
 	if r.curBuffSize == 0 {
 		var result T
-		return result, errors.New("empty list")
+		return result, newContainerError("LSQueue", "Peek", ErrEmpty)
 	}
 
 	indexOfElementToDequeue := r.getFrontElementIndex()
 	return r.data[indexOfElementToDequeue], nil
 }
 
+// Return the element of type T at position i counted from the front of the queue, where 0 is the element
+// Dequeue would return. Complexity is O(1)
+func (r *LSQueue[T]) At(i int) (T, error) {
+	var result T
+	if err := checkIndex("LSQueue", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	return r.data[(r.getFrontElementIndex()+i)%int(r.maxBuffSize)], nil
+}
+
+// Replace the element at position i counted from the front of the queue. Complexity is O(1)
+func (r *LSQueue[T]) Set(i int, element T) error {
+	if err := checkIndex("LSQueue", "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	r.data[(r.getFrontElementIndex()+i)%int(r.maxBuffSize)] = element
+	return nil
+}
+
+// Return the most recently enqueued element of type T without Dequeuing it. Complexity is O(1)
+func (r *LSQueue[T]) PeekBack() (T, error) {
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError("LSQueue", "PeekBack", ErrEmpty)
+	}
+
+	return r.data[r.lastIndex], nil
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// Complexity is O(n)
+func (r *LSQueue[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the queue satisfies f. Complexity is O(n)
+func (r *LSQueue[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
 // Return a slice representation of the current state of the queue
 func (r *LSQueue[T]) ToSlice() []T {
 	if r.curBuffSize == 0 {
@@ -119,7 +251,69 @@ func (r *LSQueue[T]) ToSlice() []T {
 	return s
 }
 
+// Return an iterator over the elements of the queue from front to back, in Dequeue order
+func (r *LSQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walkRing(r.data, r.getFrontElementIndex(), int(r.curBuffSize), func(_ int, v T) bool { return yield(v) })
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front
+func (r *LSQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walkRingBackward(r.data, r.getFrontElementIndex(), int(r.curBuffSize), yield)
+	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back
+func (r *LSQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		walkRing(r.data, r.getFrontElementIndex(), int(r.curBuffSize), yield)
+	}
+}
+
 // Return the number of elements in the queue
 func (r *LSQueue[T]) Count() uint {
 	return r.curBuffSize
 }
+
+// Remove all elements from the queue. The buffer is kept and cleared, so a cleared queue can be refilled
+// without allocating. Complexity is O(n)
+func (r *LSQueue[T]) Clear() {
+	clear(r.data)
+	r.curBuffSize = 0
+	r.lastIndex = 0
+}
+
+// Return a copy of the queue with its own buffer and the same overflow settings. The elements themselves
+// are copied by value. Complexity is O(n)
+func (r *LSQueue[T]) Clone() *LSQueue[T] {
+	return &LSQueue[T]{
+		maxBuffSize: r.maxBuffSize,
+		curBuffSize: r.curBuffSize,
+		lastIndex:   r.lastIndex,
+		data:        slices.Clone(r.data),
+		config:      r.config,
+	}
+}
+
+// Change the size of the queue to n elements. If the queue holds more than n elements the newest ones are
+// kept and the oldest are passed to the eviction callback. Complexity is O(n)
+func (r *LSQueue[T]) Resize(n uint) {
+	data := make([]T, n)
+	drop := r.curBuffSize - min(r.curBuffSize, n)
+
+	walkRing(r.data, r.getFrontElementIndex(), int(r.curBuffSize), func(i int, v T) bool {
+		if uint(i) < drop {
+			r.config.evict(v)
+		} else {
+			data[uint(i)-drop] = v
+		}
+		return true
+	})
+
+	r.data = data
+	r.maxBuffSize = n
+	r.curBuffSize -= drop
+	r.lastIndex = int(r.curBuffSize) - 1
+}
@@ -0,0 +1,256 @@
+package lists
+
+import (
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
+// Struct holding an element of a MPMCQueue. The value is boxed so a dequeuer can release it with a single
+// atomic store while other goroutines may still be reading the node
+type mpmcNode[T any] struct {
+	next  atomic.Pointer[mpmcNode[T]]
+	value atomic.Pointer[T]
+}
+
+// A hidden function which returns a node holding element
+func newMPMCNode[T any](element T) *mpmcNode[T] {
+	node := &mpmcNode[T]{}
+	node.value.Store(&element)
+	return node
+}
+
+// The MPMCQueue is an unbounded lock-free queue for any number of producer and consumer goroutines, the
+// linked queue of Michael and Scott. Producers and consumers only meet on compare-and-swap operations of
+// the tail and the head, so no goroutine ever waits for a lock held by another one that was descheduled.
+// Nodes are left to the garbage collector, which also rules out the ABA problem of the original algorithm.
+//
+// MPMCQueue is thread safe. However only the queue structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// MPMCQueue is a list that implements the Fifo interface
+type MPMCQueue[T any] struct {
+	_     cacheLinePad
+	head  atomic.Pointer[mpmcNode[T]] // sentinel whose successor is the front of the queue
+	_     cacheLinePad
+	tail  atomic.Pointer[mpmcNode[T]] // the last node, or one lagging behind while an Enqueue completes
+	_     cacheLinePad
+	count atomic.Int64
+}
+
+// The constructor for a new MPMCQueue instance with elements of type T.
+//
+// Returns a pointer to a MPMCQueue
+func NewMPMCQueue[T any]() *MPMCQueue[T] {
+	r := &MPMCQueue[T]{}
+	sentinel := &mpmcNode[T]{}
+	r.head.Store(sentinel)
+	r.tail.Store(sentinel)
+	return r
+}
+
+// A hidden method which links the chain from first to last after the last node
+func (r *MPMCQueue[T]) link(first, last *mpmcNode[T]) {
+	for {
+		tail := r.tail.Load()
+		next := tail.next.Load()
+		if next != nil {
+			// another Enqueue linked its node but hasn't moved the tail yet, help it along
+			r.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, first) {
+			r.tail.CompareAndSwap(tail, last)
+			return
+		}
+	}
+}
+
+// A hidden method which returns the box of the front element without removing it, or nil if the queue is empty
+func (r *MPMCQueue[T]) front() *T {
+	for {
+		next := r.head.Load().next.Load()
+		if next == nil {
+			return nil
+		}
+		if p := next.value.Load(); p != nil {
+			return p
+		}
+		// the front was dequeued while reading it
+	}
+}
+
+// Return the number of elements in the queue. -1 means unlimited
+func (r *MPMCQueue[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of elements in the queue. While other goroutines are active the count may be
+// outdated as soon as it is returned
+func (r *MPMCQueue[T]) Count() uint {
+	return uint(max(r.count.Load(), 0))
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *MPMCQueue[T]) IsEmpty() bool {
+	return r.head.Load().next.Load() == nil
+}
+
+// Checks if the queue is full. Can never be full but just for interface implementation
+func (r *MPMCQueue[T]) IsFull() bool {
+	return false
+}
+
+// Add an element of type T to the end of the queue. Complexity is O(1)
+func (r *MPMCQueue[T]) Enqueue(element T) {
+	node := newMPMCNode(element)
+	r.count.Add(1)
+	r.link(node, node)
+}
+
+// Add elements of type T to the end of the queue in the given order. Complexity is O(k)
+func (r *MPMCQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order. The elements are linked together first
+// and appear in the queue at once, so elements of other producers never end up between them. Complexity is O(k)
+func (r *MPMCQueue[T]) EnqueueSlice(elements []T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	// nodes are allocated one by one so the sentinel left by a Dequeue doesn't keep the rest of the batch reachable
+	first := newMPMCNode(elements[0])
+	last := first
+	for _, element := range elements[1:] {
+		node := newMPMCNode(element)
+		last.next.Store(node)
+		last = node
+	}
+	r.count.Add(int64(len(elements)))
+	r.link(first, last)
+}
+
+// Remove and return an element of type T from the beginning of the queue. Complexity is O(1)
+func (r *MPMCQueue[T]) Dequeue() (T, error) {
+	for {
+		head := r.head.Load()
+		next := head.next.Load()
+		if next == nil {
+			var result T
+			return result, newContainerError("MPMCQueue", "Dequeue", ErrEmpty)
+		}
+		if tail := r.tail.Load(); tail == head {
+			// the tail lags behind the node about to be dequeued, move it first
+			r.tail.CompareAndSwap(tail, next)
+		}
+
+		if r.head.CompareAndSwap(head, next) {
+			// next is the new sentinel and only this goroutine takes its value
+			r.count.Add(-1)
+			return *next.value.Swap(nil), nil
+		}
+	}
+}
+
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// Elements of other consumers may be taken in between. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *MPMCQueue[T]) DequeueInto(dst []T) int {
+	for i := range dst {
+		element, err := r.Dequeue()
+		if err != nil {
+			return i
+		}
+		dst[i] = element
+	}
+	return len(dst)
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order. Complexity is O(n)
+func (r *MPMCQueue[T]) DequeueN(n int) []T {
+	s := make([]T, 0, min(max(n, 0), int(r.Count())))
+	for len(s) < n {
+		element, err := r.Dequeue()
+		if err != nil {
+			break
+		}
+		s = append(s, element)
+	}
+	return s
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue
+func (r *MPMCQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an element of type T from the beginning of the queue without Dequeuing it. Complexity is O(1)
+func (r *MPMCQueue[T]) Peek() (T, error) {
+	if p := r.front(); p != nil {
+		return *p, nil
+	}
+
+	var result T
+	return result, newContainerError("MPMCQueue", "Peek", ErrEmpty)
+}
+
+// Return a slice representation of the queue in Dequeue order. Complexity is O(n)
+func (r *MPMCQueue[T]) ToSlice() []T {
+	s := make([]T, 0, r.Count())
+	for element := range r.All() {
+		s = append(s, element)
+	}
+	return s
+}
+
+// Return an iterator over the elements of the queue from front to back, in Dequeue order.
+// The iterator walks the nodes while other goroutines use the queue: elements dequeued meanwhile are
+// skipped and elements enqueued meanwhile may be included
+func (r *MPMCQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := r.head.Load().next.Load(); node != nil; node = node.next.Load() {
+			if p := node.value.Load(); p != nil && !yield(*p) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front. The queue is only linked
+// forward, so the elements are collected first. Complexity is O(n)
+func (r *MPMCQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := r.ToSlice()
+		for _, element := range slices.Backward(s) {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back
+func (r *MPMCQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for element := range r.All() {
+			if !yield(i, element) {
+				return
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package lists
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// The number of elements each producer of stressFifo enqueues. Producer p enqueues p*stressElements and up
+const stressElements = 20000
+
+// Run producers and consumers on queue at once and check every element comes out exactly once and, if
+// ordered, the elements of each producer in the order they went in
+func stressFifo(t *testing.T, name string, queue Fifo[int], ordered bool) {
+	const producers, consumers, n = 4, 4, stressElements
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; {
+				if i%5 == 0 {
+					queue.EnqueueAll(p*n+i, p*n+i+1, p*n+i+2)
+					i += 3
+					continue
+				}
+				queue.Enqueue(p*n + i)
+				i++
+			}
+		}(p)
+	}
+
+	seen := make([][]int, consumers)
+	done := make(chan struct{})
+	var consumed sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func(c int) {
+			defer consumed.Done()
+			for {
+				// readers run alongside the consumers
+				queue.Peek()
+				element, err := queue.Dequeue()
+				if err == nil {
+					seen[c] = append(seen[c], element)
+					continue
+				}
+				select {
+				case <-done:
+					if queue.IsEmpty() {
+						return
+					}
+				default:
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+
+	wg.Wait()
+	close(done)
+	consumed.Wait()
+
+	count := make([]int, producers*n)
+	for c := range seen {
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, element := range seen[c] {
+			count[element]++
+			if p := element / n; ordered && element <= last[p] {
+				t.Fatalf("%v consumer %v got %v after %v", name, c, element, last[p])
+			} else {
+				last[p] = element
+			}
+		}
+	}
+	for element, k := range count {
+		if k != 1 {
+			t.Fatalf("%v element %v dequeued %v times, want %v", name, element, k, 1)
+		}
+	}
+	if !queue.IsEmpty() || queue.Count() != 0 {
+		t.Errorf("%v IsEmpty(), Count() = %v, %v, want %v, %v", name, queue.IsEmpty(), queue.Count(), true, 0)
+	}
+}
+
+func TestMPMCQueueConcurrent(t *testing.T) {
+	stressFifo(t, "MPMCQueue", NewMPMCQueue[int](), true)
+	// a small ring keeps producers waiting for consumers
+	stressFifo(t, "BoundedMPMCQueue", NewBoundedMPMCQueue[int](64), true)
+}
+
+func TestMPMCQueueIteratorsConcurrent(t *testing.T) {
+	queues := map[string]Fifo[int]{
+		"MPMCQueue":        NewMPMCQueue[int](),
+		"BoundedMPMCQueue": NewBoundedMPMCQueue[int](256),
+	}
+
+	for name, queue := range queues {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				queue.Enqueue(i)
+				queue.Dequeue()
+			}
+		}()
+
+		// iterating while elements come and go sees increasing elements only
+		for k := 0; k < 100; k++ {
+			last := -1
+			for element := range queue.All() {
+				if element <= last {
+					t.Fatalf("%v All() = %v after %v", name, element, last)
+				}
+				last = element
+			}
+		}
+		wg.Wait()
+	}
+}
+
+func TestBoundedMPMCQueueFull(t *testing.T) {
+	queue := NewBoundedMPMCQueue[int](3)
+
+	if queue.Capacity() != 4 {
+		t.Errorf("Capacity() = %v, want %v", queue.Capacity(), 4)
+	}
+	queue.EnqueueAll(0, 1, 2, 3)
+	if err := queue.TryEnqueue(4); !errors.Is(err, ErrFull) || !queue.IsFull() {
+		t.Errorf("TryEnqueue() on a full queue error = %v, want %v", err, ErrFull)
+	}
+	queue.Dequeue()
+	if err := queue.TryEnqueue(4); err != nil {
+		t.Errorf("TryEnqueue() error = %v, want %v", err, nil)
+	}
+	if element, _ := queue.Peek(); element != 1 {
+		t.Errorf("Peek() = %v, want %v", element, 1)
+	}
+}
+
+// Every goroutine enqueues and dequeues in turn
+func benchmarkParallelFifo(b *testing.B, queue Fifo[int]) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			queue.Enqueue(i)
+			queue.Dequeue()
+			i++
+		}
+	})
+}
+
+func BenchmarkParallelSafeQueue(b *testing.B) {
+	benchmarkParallelFifo(b, NewSafeQueue[int]())
+}
+
+func BenchmarkParallelMPMCQueue(b *testing.B) {
+	benchmarkParallelFifo(b, NewMPMCQueue[int]())
+}
+
+func BenchmarkParallelBoundedMPMCQueue(b *testing.B) {
+	benchmarkParallelFifo(b, NewBoundedMPMCQueue[int](1024))
+}
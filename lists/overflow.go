@@ -0,0 +1,65 @@
+package lists
+
+// OverflowPolicy selects what a limited size queue does with an incoming element once it is full
+type OverflowPolicy int
+
+const (
+	// Discard the oldest element to make room for the incoming one. This is the default
+	DropOldest OverflowPolicy = iota
+	// Discard the incoming element and keep the queue as it is. The element is passed to the eviction
+	// callback, and TryEnqueue also reports the drop with an error
+	DropNewest
+	// Refuse the incoming element. TryEnqueue hands it back to the caller with an error, without calling
+	// the eviction callback, while Enqueue discards it and passes it to the eviction callback
+	Reject
+	// Wait until a consumer makes room. Only SafeLSQueue can block. LSQueue has no other goroutine to make
+	// room and treats Block like Reject, and so does TryEnqueue of SafeLSQueue
+	Block
+)
+
+// Struct holding the settings of a limited size queue
+type lsQueueConfig[T any] struct {
+	policy  OverflowPolicy
+	onEvict func(evicted T)
+}
+
+// LSQueueOption configures an LSQueue or SafeLSQueue when passed to its constructor
+type LSQueueOption[T any] func(*lsQueueConfig[T])
+
+// Select what happens to an incoming element once the queue is full. Defaults to DropOldest
+func WithOverflowPolicy[T any](policy OverflowPolicy) LSQueueOption[T] {
+	return func(c *lsQueueConfig[T]) {
+		c.policy = policy
+	}
+}
+
+// Register a callback invoked with every element the queue discards because of its overflow policy, so
+// drops can be counted. Elements refused by TryEnqueue under Reject or Block are handed back to the caller
+// through the error and are not passed to it.
+func WithEvictionCallback[T any](fn func(evicted T)) LSQueueOption[T] {
+	return func(c *lsQueueConfig[T]) {
+		c.onEvict = fn
+	}
+}
+
+// A hidden function which applies opts over the default settings
+func newLSQueueConfig[T any](opts []LSQueueOption[T]) lsQueueConfig[T] {
+	c := lsQueueConfig[T]{policy: DropOldest}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// A hidden method which reports whether TryEnqueue drops an incoming element it has no room for, as
+// DropNewest and DropOldest on a queue of size 0 do, instead of handing it back to the caller
+func (c *lsQueueConfig[T]) dropsRefused() bool {
+	return c.policy != Reject && c.policy != Block
+}
+
+// A hidden method which hands a discarded element to the eviction callback, if any
+func (c *lsQueueConfig[T]) evict(element T) {
+	if c.onEvict != nil {
+		c.onEvict(element)
+	}
+}
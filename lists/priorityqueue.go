@@ -0,0 +1,395 @@
+package lists
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// PriorityElement is a handle to a single entry of a PriorityQueue. Holding on to a PriorityElement allows
+// the priority of the entry to be changed, or the entry to be removed, in O(log n) without searching for it.
+type PriorityElement[T any] struct {
+	index int    // position in the heap, -1 once the element left the queue
+	seq   uint64 // insertion order, used to break ties between equal priorities
+	queue *PriorityQueue[T]
+
+	// The value stored in this element. After changing it in place call Fix, or use Update instead
+	Value T
+}
+
+// The PriorityQueue is a collection of entities which are dequeued in order of priority rather than in
+// the order they were enqueued. It is a binary heap ordered by a less function: an element a is dequeued
+// before an element b if less(a, b) is true. Elements of equal priority are dequeued in the order they
+// were enqueued.
+//
+// Every insertion through Push returns a PriorityElement handle which can later be used to change the
+// priority of that entry with Update or Fix, or to remove it.
+//
+// PriorityQueue is a list that implements the Fifo interface, where the front of the queue is the element
+// with the highest priority.
+type PriorityQueue[T any] struct {
+	heap []*PriorityElement[T]
+	less func(a, b T) bool
+	seq  uint64
+	kind string // the container type named by errors, "SafePriorityQueue" inside a SafePriorityQueue
+}
+
+// The constructor for a new PriorityQueue instance with elements of type T, where less(a, b) reports
+// whether a must be dequeued before b.
+//
+// Returns a pointer to a PriorityQueue
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		heap: make([]*PriorityElement[T], 0),
+		less: less,
+		kind: "PriorityQueue",
+	}
+}
+
+// The constructor for a new PriorityQueue instance with ordered elements of type T, which dequeues the
+// smallest element first.
+//
+// Returns a pointer to a PriorityQueue
+func NewOrderedPriorityQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Less[T])
+}
+
+// A hidden method which reports whether element a must be dequeued before element b
+func (r *PriorityQueue[T]) before(a, b *PriorityElement[T]) bool {
+	if r.less(a.Value, b.Value) {
+		return true
+	}
+	if r.less(b.Value, a.Value) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+// A hidden method which swaps the elements at positions i and j of the heap
+func (r *PriorityQueue[T]) swap(i, j int) {
+	r.heap[i], r.heap[j] = r.heap[j], r.heap[i]
+	r.heap[i].index = i
+	r.heap[j].index = j
+}
+
+// A hidden method which moves the element at position i up until its parent comes before it
+func (r *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !r.before(r.heap[i], r.heap[parent]) {
+			break
+		}
+		r.swap(i, parent)
+		i = parent
+	}
+}
+
+// A hidden method which counts the elements whose Value satisfies f, given that f is also satisfied by every
+// element dequeued before such an element. A parent comes before its children, so the subtree below an
+// element failing f is skipped. Complexity is O(k) for k counted elements
+func (r *PriorityQueue[T]) countWhile(f func(T) bool) int {
+	count := 0
+	pending := []int{0}
+	for len(pending) > 0 {
+		i := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if i >= len(r.heap) || !f(r.heap[i].Value) {
+			continue
+		}
+		count++
+		pending = append(pending, 2*i+1, 2*i+2)
+	}
+	return count
+}
+
+// A hidden method which moves the element at position i down until it comes before its children
+//
+// Returns true if the element moved
+func (r *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(r.heap)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && r.before(r.heap[right], r.heap[child]) {
+			child = right
+		}
+		if !r.before(r.heap[child], r.heap[i]) {
+			break
+		}
+		r.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// A hidden method which restores the heap order after the element at position i changed
+func (r *PriorityQueue[T]) fix(i int) {
+	if !r.down(i) {
+		r.up(i)
+	}
+}
+
+// A hidden method which wraps value in a new element and appends it to the heap without ordering it
+func (r *PriorityQueue[T]) add(value T) *PriorityElement[T] {
+	e := &PriorityElement[T]{
+		index: len(r.heap),
+		seq:   r.seq,
+		queue: r,
+		Value: value,
+	}
+	r.seq++
+	r.heap = append(r.heap, e)
+	return e
+}
+
+// A hidden method which unlinks the element at position i from the heap and returns it
+func (r *PriorityQueue[T]) remove(i int) *PriorityElement[T] {
+	last := len(r.heap) - 1
+	if i != last {
+		r.swap(i, last)
+	}
+
+	e := r.heap[last]
+	r.heap[last] = nil // avoid memory leaks
+	r.heap = r.heap[:last]
+	if i != last {
+		r.fix(i)
+	}
+
+	e.index = -1
+	e.queue = nil
+	return e
+}
+
+// A hidden method which returns the elements of the heap in Dequeue order
+func (r *PriorityQueue[T]) sorted() []*PriorityElement[T] {
+	s := slices.Clone(r.heap)
+	slices.SortFunc(s, func(a, b *PriorityElement[T]) int {
+		if r.before(a, b) {
+			return -1
+		}
+		if r.before(b, a) {
+			return 1
+		}
+		return 0
+	})
+	return s
+}
+
+// Return the number of elements in the queue. -1 means unlimited
+func (r *PriorityQueue[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of elements in the queue
+func (r *PriorityQueue[T]) Count() uint {
+	return uint(len(r.heap))
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *PriorityQueue[T]) IsEmpty() bool {
+	return len(r.heap) == 0
+}
+
+// Checks if the queue is full. Can never be full but just for interface implementation
+func (r *PriorityQueue[T]) IsFull() bool {
+	return false
+}
+
+// Add a new element with the given value to the queue and return it. Complexity is O(log n)
+func (r *PriorityQueue[T]) Push(value T) *PriorityElement[T] {
+	e := r.add(value)
+	r.up(e.index)
+	return e
+}
+
+// Add an element of type T to the queue. Same as Push without returning the handle
+func (r *PriorityQueue[T]) Enqueue(element T) {
+	r.Push(element)
+}
+
+// Add elements of type T to the queue. Elements of equal priority keep the given order. Complexity is O(k log n)
+func (r *PriorityQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the queue. Elements of equal priority keep the given order.
+// When the slice holds at least as many elements as the queue, the heap is rebuilt in one pass.
+// Complexity is O(min(k log n, n + k))
+func (r *PriorityQueue[T]) EnqueueSlice(elements []T) {
+	if len(elements) < len(r.heap) {
+		for _, element := range elements {
+			r.Push(element)
+		}
+		return
+	}
+
+	for _, element := range elements {
+		r.add(element)
+	}
+	for i := len(r.heap)/2 - 1; i >= 0; i-- {
+		r.down(i)
+	}
+}
+
+// Remove and return the element of type T with the highest priority. Complexity is O(log n)
+func (r *PriorityQueue[T]) Dequeue() (T, error) {
+	var result T
+	if len(r.heap) == 0 {
+		return result, newContainerError(r.kind, "Dequeue", ErrEmpty)
+	}
+
+	return r.remove(0).Value, nil
+}
+
+// Remove up to len(dst) elements in order of priority and copy them into dst. Complexity is O(k log n)
+//
+// Returns the number of elements copied
+func (r *PriorityQueue[T]) DequeueInto(dst []T) int {
+	n := 0
+	for n < len(dst) && len(r.heap) > 0 {
+		dst[n] = r.remove(0).Value
+		n++
+	}
+	return n
+}
+
+// Remove up to n elements in order of priority and return them. Complexity is O(n log n)
+func (r *PriorityQueue[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), len(r.heap)))
+	return s[:r.DequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements in order of priority until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue
+func (r *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(r.heap) > 0 {
+			if !yield(r.remove(0).Value) {
+				return
+			}
+		}
+	}
+}
+
+// Return the element of type T with the highest priority without Dequeuing it. Complexity is O(1)
+func (r *PriorityQueue[T]) Peek() (T, error) {
+	var result T
+	if len(r.heap) == 0 {
+		return result, newContainerError(r.kind, "Peek", ErrEmpty)
+	}
+
+	return r.heap[0].Value, nil
+}
+
+// Return the element with the highest priority without Dequeuing it, or nil if the queue is empty
+func (r *PriorityQueue[T]) Front() *PriorityElement[T] {
+	if len(r.heap) == 0 {
+		return nil
+	}
+	return r.heap[0]
+}
+
+// Restore the order of the queue after the Value of e was changed in place. If e is not an element of
+// this queue, the queue is not modified. Complexity is O(log n)
+func (r *PriorityQueue[T]) Fix(e *PriorityElement[T]) {
+	if e.queue != r {
+		return
+	}
+	r.fix(e.index)
+}
+
+// Change the Value of e and move it to its new place in the queue. If e is not an element of this queue,
+// the queue is not modified. Complexity is O(log n)
+func (r *PriorityQueue[T]) Update(e *PriorityElement[T], value T) {
+	if e.queue != r {
+		return
+	}
+	e.Value = value
+	r.fix(e.index)
+}
+
+// Remove e from the queue if it is an element of this queue and return its value. Complexity is O(log n)
+func (r *PriorityQueue[T]) Remove(e *PriorityElement[T]) T {
+	if e.queue == r {
+		r.remove(e.index)
+	}
+	return e.Value
+}
+
+// Remove all elements from the queue. The removed elements are unlinked, so PriorityElement handles
+// held elsewhere no longer refer to the queue. Complexity is O(n)
+func (r *PriorityQueue[T]) Clear() {
+	for i, e := range r.heap {
+		e.index = -1
+		e.queue = nil
+		r.heap[i] = nil // avoid memory leaks
+	}
+	r.heap = r.heap[:0]
+}
+
+// Return a copy of the queue with new elements holding the same values. The values themselves are copied
+// by value. Complexity is O(n)
+func (r *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	c := &PriorityQueue[T]{
+		heap: make([]*PriorityElement[T], len(r.heap)),
+		less: r.less,
+		seq:  r.seq,
+		kind: r.kind,
+	}
+	for i, e := range r.heap {
+		c.heap[i] = &PriorityElement[T]{index: i, seq: e.seq, queue: c, Value: e.Value}
+	}
+	return c
+}
+
+// Return a slice representation of the current state of the queue in Dequeue order. Complexity is O(n log n)
+func (r *PriorityQueue[T]) ToSlice() []T {
+	s := make([]T, 0, len(r.heap))
+	for v := range r.All() {
+		s = append(s, v)
+	}
+	return s
+}
+
+// Return an iterator over the elements of the queue in Dequeue order. The elements are sorted up front,
+// so the iteration costs O(n log n)
+func (r *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, e := range r.sorted() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the queue in reverse Dequeue order, lowest priority first.
+// The elements are sorted up front, so the iteration costs O(n log n)
+func (r *PriorityQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := r.sorted()
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i].Value) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the queue in Dequeue order. The elements are
+// sorted up front, so the iteration costs O(n log n)
+func (r *PriorityQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, e := range r.sorted() {
+			if !yield(i, e.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package lists
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type job struct {
+	name     string
+	priority int
+}
+
+func TestPriorityQueue(t *testing.T) {
+	var queue Fifo[int] = NewOrderedPriorityQueue[int]()
+
+	if _, err := queue.Dequeue(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Dequeue() on an empty queue error = %v, want %v", err, ErrEmpty)
+	}
+
+	values := rand.Perm(100)
+	for _, v := range values {
+		queue.Enqueue(v)
+	}
+
+	if queue.Count() != 100 {
+		t.Errorf("Count() = %v, want %v", queue.Count(), 100)
+	}
+	if element, _ := queue.Peek(); element != 0 {
+		t.Errorf("Peek() = %v, want %v", element, 0)
+	}
+
+	// the iterators follow Dequeue order
+	want := slices.Sorted(slices.Values(values))
+	if s := queue.ToSlice(); !slices.Equal(s, want) {
+		t.Errorf("ToSlice() = %v, want %v", s, want)
+	}
+	backward := slices.Clone(want)
+	slices.Reverse(backward)
+	if s := slices.Collect(queue.Backward()); !slices.Equal(s, backward) {
+		t.Errorf("Backward() = %v, want %v", s, backward)
+	}
+
+	for i := 0; i < 100; i++ {
+		if element, err := queue.Dequeue(); element != i || err != nil {
+			t.Errorf("Dequeue() = %v, %v, want %v, %v", element, err, i, nil)
+		}
+	}
+	if !queue.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", queue.IsEmpty(), true)
+	}
+}
+
+func TestPriorityQueueStable(t *testing.T) {
+	byPriority := func(a, b job) bool { return a.priority > b.priority }
+
+	queues := map[string]Fifo[job]{
+		"PriorityQueue":     NewPriorityQueue(byPriority),
+		"SafePriorityQueue": NewSafePriorityQueue(byPriority),
+	}
+
+	for name, queue := range queues {
+		// jobs of equal priority come out in the order they went in, both when enqueued one
+		// by one and when the heap is rebuilt for a batch
+		queue.Enqueue(job{"a", 1})
+		queue.Enqueue(job{"b", 2})
+		queue.EnqueueAll(job{"c", 1}, job{"d", 3}, job{"e", 2}, job{"f", 1})
+		queue.EnqueueAll(job{"g", 3})
+
+		names := ""
+		for j := range queue.Drain() {
+			names += j.name
+		}
+		if names != "dgbeacf" {
+			t.Errorf("%v Drain() order = %v, want %v", name, names, "dgbeacf")
+		}
+	}
+}
+
+func TestPriorityQueueUpdate(t *testing.T) {
+	queue := NewOrderedPriorityQueue[int]()
+	handles := make([]*PriorityElement[int], 10)
+	for i := range handles {
+		handles[i] = queue.Push(i * 10)
+	}
+
+	// raise and lower priorities through the handles
+	queue.Update(handles[7], -1)
+	handles[0].Value = 55
+	queue.Fix(handles[0])
+
+	if element, _ := queue.Peek(); element != -1 {
+		t.Errorf("Peek() = %v, want %v", element, -1)
+	}
+	if front := queue.Front(); front != handles[7] {
+		t.Errorf("Front() = %v, want the updated element", front.Value)
+	}
+
+	if v := queue.Remove(handles[3]); v != 30 {
+		t.Errorf("Remove() = %v, want %v", v, 30)
+	}
+	// a removed element no longer belongs to the queue
+	queue.Update(handles[3], -5)
+	queue.Remove(handles[3])
+
+	want := []int{-1, 10, 20, 40, 50, 55, 60, 80, 90}
+	if s := queue.DequeueN(20); !slices.Equal(s, want) {
+		t.Errorf("DequeueN() = %v, want %v", s, want)
+	}
+
+	// handles of dequeued elements are detached too
+	other := NewOrderedPriorityQueue[int]()
+	other.Push(1)
+	other.Update(handles[1], 0)
+	if s := other.ToSlice(); !slices.Equal(s, []int{1}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1})
+	}
+}
+
+func TestPriorityQueueClearClone(t *testing.T) {
+	queue := NewOrderedPriorityQueue[int]()
+	e := queue.Push(5)
+	queue.EnqueueAll(3, 8, 1)
+
+	clone := queue.Clone()
+	queue.Update(e, 0)
+	if s := clone.ToSlice(); !slices.Equal(s, []int{1, 3, 5, 8}) {
+		t.Errorf("Clone().ToSlice() = %v, want %v", s, []int{1, 3, 5, 8})
+	}
+
+	queue.Clear()
+	if !queue.IsEmpty() {
+		t.Errorf("IsEmpty() after Clear() = %v, want %v", queue.IsEmpty(), true)
+	}
+	queue.Update(e, 9)
+	if !queue.IsEmpty() || e.Value != 0 {
+		t.Errorf("Update() on a cleared element changed the queue")
+	}
+}
+
+func TestSafePriorityQueueConcurrent(t *testing.T) {
+	queue := NewSafeOrderedPriorityQueue[int]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				e := queue.Push(p*1000 + i)
+				queue.Update(e, p*1000+i)
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	s := queue.DequeueN(1000)
+	if len(s) != 1000 || !slices.IsSorted(s) {
+		t.Errorf("DequeueN() returned %v sorted elements = %v, want %v, %v", len(s), slices.IsSorted(s), 1000, true)
+	}
+}
+
+func BenchmarkPriorityQueueEnqueue(b *testing.B) {
+	queue := NewOrderedPriorityQueue[int]()
+	values := rand.Perm(b.N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(values[i])
+	}
+}
+
+func BenchmarkPriorityQueueDequeue(b *testing.B) {
+	queue := NewOrderedPriorityQueue[int]()
+	queue.EnqueueSlice(rand.Perm(b.N))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Dequeue()
+	}
+}
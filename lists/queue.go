@@ -1,8 +1,6 @@
 package lists
 
-import (
-	"errors"
-)
+import "iter"
 
 // The Queue is a collection of entities that are maintained in a sequence and can be modified
 // by the addition of entities at one end of the sequence and the removal of entities from the
@@ -13,19 +11,25 @@ type Queue[T any] struct {
 	curBuffSize uint
 	headIndex   uint
 	tailIndex   uint
+	chunkSize   uint
+	pool        chunkPool[T]
 	head        *arrnode[T]
 	tail        *arrnode[T]
 }
 
 // The constructor for a new Queue instance with elements of type T.
+// WithChunkSize changes the number of elements stored per chunk.
 //
 // Returns a pointer to a queue
-func NewQueue[T any]() Fifo[T] {
-	node := newArrayNode[T](nil)
+func NewQueue[T any](opts ...ChunkOption) *Queue[T] {
+	config := newChunkConfig(opts)
+	node := newArrayNode[T](nil, config.chunkSize)
 	return &Queue[T]{
 		curBuffSize: 0,
 		headIndex:   0,
 		tailIndex:   0,
+		chunkSize:   config.chunkSize,
+		pool:        newChunkPool[T](config),
 		head:        node,
 		tail:        node,
 	}
@@ -40,9 +44,9 @@ func (r *Queue[T]) Capacity() int {
 func (r *Queue[T]) Enqueue(element T) {
 	r.tail.write(element, int(r.tailIndex))
 
-	if r.tailIndex == 999 {
-		// prepare a new tail node for extra 1000 entries
-		node := newArrayNode[T](nil)
+	if r.tailIndex == r.chunkSize-1 {
+		// prepare a new tail node for another chunk of entries
+		node := r.pool.get(nil)
 		r.tail.next = node
 		r.tail = r.tail.next
 		r.tailIndex = 0
@@ -52,19 +56,45 @@ func (r *Queue[T]) Enqueue(element T) {
 	r.curBuffSize++
 }
 
+// Add elements of type T to the end of the queue in the given order. Complexity is O(k)
+func (r *Queue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order. Elements are copied into the tail node
+// a whole run at a time. Complexity is O(k)
+func (r *Queue[T]) EnqueueSlice(elements []T) {
+	for len(elements) > 0 {
+		k := copy(r.tail.data[r.tailIndex:], elements)
+		elements = elements[k:]
+		r.tailIndex += uint(k)
+		r.curBuffSize += uint(k)
+
+		if r.tailIndex == r.chunkSize {
+			// prepare a new tail node for another chunk of entries
+			node := r.pool.get(nil)
+			r.tail.next = node
+			r.tail = r.tail.next
+			r.tailIndex = 0
+		}
+	}
+}
+
 // Remove and return am element of type T from the beginning of the queue. Complexity is O(1)
 func (r *Queue[T]) Dequeue() (T, error) {
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("Queue", "Dequeue", ErrEmpty)
 	}
 
 	r.curBuffSize--
-	result = r.head.read(int(r.headIndex))
+	result = r.head.take(int(r.headIndex))
 
-	if r.headIndex == 999 {
+	if r.headIndex == r.chunkSize-1 {
 		r.headIndex = 0
+		node := r.head
 		r.head = r.head.next
+		r.pool.put(node)
 	} else {
 		r.headIndex++
 	}
@@ -77,6 +107,57 @@ func (r *Queue[T]) Dequeue() (T, error) {
 	return result, nil
 }
 
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *Queue[T]) DequeueInto(dst []T) int {
+	n := 0
+	for n < len(dst) && r.curBuffSize > 0 {
+		// copy the run of elements left in the head node in one go
+		end := min(r.chunkSize, r.headIndex+r.curBuffSize)
+		k := uint(copy(dst[n:], r.head.data[r.headIndex:end]))
+		clear(r.head.data[r.headIndex : r.headIndex+k])
+
+		n += int(k)
+		r.curBuffSize -= k
+		r.headIndex += k
+
+		if r.headIndex == r.chunkSize {
+			r.headIndex = 0
+			node := r.head
+			r.head = r.head.next
+			r.pool.put(node)
+		}
+	}
+
+	if r.curBuffSize == 0 {
+		r.headIndex = 0
+		r.tailIndex = 0
+	}
+
+	return n
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order. Complexity is O(n)
+func (r *Queue[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.DequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue
+func (r *Queue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r.curBuffSize > 0 {
+			element, _ := r.Dequeue()
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
 // Checks if the queue is empty
 //
 // Return true if empty false otherwise
@@ -93,7 +174,7 @@ func (r *Queue[T]) IsFull() bool {
 func (r *Queue[T]) Peek() (T, error) {
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("Queue", "Peek", ErrEmpty)
 	}
 
 	result = r.head.read(int(r.headIndex))
@@ -101,36 +182,119 @@ func (r *Queue[T]) Peek() (T, error) {
 	return result, nil
 }
 
-// Return a slice representation of the current state of the queue
-func (r *Queue[T]) ToSlice() []T {
-	ret := make([]T, 0)
+// Return the element of type T at position i counted from the front of the queue, where 0 is the element
+// Dequeue would return. Complexity is O(i/chunk size)
+func (r *Queue[T]) At(i int) (T, error) {
+	var result T
+	if err := checkIndex("Queue", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	node, pos := locateChain(r.head, r.headIndex, uint(i))
+	return node.read(pos), nil
+}
+
+// Replace the element at position i counted from the front of the queue. Complexity is O(i/chunk size)
+func (r *Queue[T]) Set(i int, element T) error {
+	if err := checkIndex("Queue", "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	node, pos := locateChain(r.head, r.headIndex, uint(i))
+	node.write(element, pos)
+	return nil
+}
+
+// Return the most recently enqueued element of type T without Dequeuing it. Complexity is O(1), except when
+// the last chunk was just filled which takes O(n/chunk size)
+func (r *Queue[T]) PeekBack() (T, error) {
+	var result T
 	if r.curBuffSize == 0 {
-		return ret
+		return result, newContainerError("Queue", "PeekBack", ErrEmpty)
 	}
 
-	startingIndex := r.headIndex
-	startingNode := r.head
-	finished := false
+	if r.tailIndex > 0 {
+		return r.tail.read(int(r.tailIndex - 1)), nil
+	}
+	node, pos := locateChain(r.head, r.headIndex, r.curBuffSize-1)
+	return node.read(pos), nil
+}
 
-	for startingNode != nil && !finished {
-		ret = append(ret, startingNode.data[startingIndex])
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// Complexity is O(n)
+func (r *Queue[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
 
-		if r.tail == startingNode && (startingIndex+1) == r.tailIndex {
-			return ret
-		}
+// Report whether any element of the queue satisfies f. Complexity is O(n)
+func (r *Queue[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
 
-		if startingIndex == 999 {
-			startingNode = startingNode.next
-			startingIndex = 0
-		} else {
-			startingIndex++
-		}
+// Return a slice representation of the current state of the queue
+func (r *Queue[T]) ToSlice() []T {
+	s := make([]T, 0, r.curBuffSize)
+	for v := range r.All() {
+		s = append(s, v)
+	}
+	return s
+}
+
+// Return an iterator over the elements of the queue from front to back, in Dequeue order
+func (r *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walkChain(r.head, r.headIndex, r.curBuffSize, func(_ int, v T) bool { return yield(v) })
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front
+func (r *Queue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walkChainBackward(r.head, r.headIndex, r.curBuffSize, yield)
 	}
+}
 
-	return ret
+// Return an iterator over the positions and elements of the queue from front to back
+func (r *Queue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		walkChain(r.head, r.headIndex, r.curBuffSize, yield)
+	}
 }
 
 // Return the number of elements in the queue
 func (r *Queue[T]) Count() uint {
 	return r.curBuffSize
 }
+
+// Remove all elements from the queue. The head chunk is kept and the others are recycled, so a cleared
+// queue can be refilled without allocating. Complexity is O(n)
+func (r *Queue[T]) Clear() {
+	r.pool.putChain(r.head.next)
+	r.head.next = nil
+	clear(r.head.data)
+
+	r.tail = r.head
+	r.curBuffSize = 0
+	r.headIndex = 0
+	r.tailIndex = 0
+}
+
+// Return a copy of the queue which shares no chunks with it. The elements themselves are copied by value.
+// Complexity is O(n)
+func (r *Queue[T]) Clone() *Queue[T] {
+	head, tail := cloneChain(r.head)
+	return &Queue[T]{
+		curBuffSize: r.curBuffSize,
+		headIndex:   r.headIndex,
+		tailIndex:   r.tailIndex,
+		chunkSize:   r.chunkSize,
+		pool:        r.pool.clone(),
+		head:        head,
+		tail:        tail,
+	}
+}
+
+// Release the spare chunks kept for reuse, see WithSpareChunks
+func (r *Queue[T]) Shrink() {
+	r.pool.shrink()
+}
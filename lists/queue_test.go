@@ -1,7 +1,13 @@
 package lists
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestQueue(t *testing.T) {
@@ -115,30 +121,646 @@ func TestLSQueue(t *testing.T) {
 	}
 
 	// test if full
-	if queue.IsFull() {
-		t.Errorf("IsFull() = %v, want %v", queue.IsFull(), false)
+	if !queue.IsFull() {
+		t.Errorf("IsFull() = %v, want %v", queue.IsFull(), true)
 	}
 
 	// peak the first element
 	element, err := queue.Peek()
-	if element != 901 || err != nil {
-		t.Errorf("Peek() = %v, %v, want %v, %v", element, err, 901, nil)
+	if element != 900 || err != nil {
+		t.Errorf("Peek() = %v, %v, want %v, %v", element, err, 900, nil)
 	}
 
 	// check Count method
-	if queue.Count() != 99 {
-		t.Errorf("Count() = %v, want %v", queue.Count(), 99)
+	if queue.Count() != 100 {
+		t.Errorf("Count() = %v, want %v", queue.Count(), 100)
 	}
 
-	// dequeue 1000 elements
+	// dequeue 100 elements
 	for i := 0; i < 100; i++ {
 		element, _ := queue.Dequeue()
-		if !queue.IsEmpty() && element != i+901 {
-			t.Errorf("Dequeue() = %v, want %v", element, i+901)
+		if element != i+900 {
+			t.Errorf("Dequeue() = %v, want %v", element, i+900)
+		}
+	}
+
+	// test if empty
+	if !queue.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", queue.IsEmpty(), true)
+	}
+}
+
+// Before 2.0.0 a limited size queue held one element less than its size and never reported being full
+func TestLSQueueHoldsSize(t *testing.T) {
+	for name, queue := range map[string]Fifo[int]{
+		"LSQueue":     NewLSQueue[int](3),
+		"SafeLSQueue": NewSafeLSQueue[int](3),
+	} {
+		queue.EnqueueAll(0, 1)
+		if queue.IsFull() {
+			t.Errorf("%v IsFull() with 2 of 3 elements = %v, want %v", name, true, false)
+		}
+		queue.Enqueue(2)
+		if !queue.IsFull() || queue.Count() != 3 {
+			t.Errorf("%v IsFull(), Count() = %v, %v, want %v, %v", name, queue.IsFull(), queue.Count(), true, 3)
+		}
+		queue.Enqueue(3)
+		if s := queue.ToSlice(); !slices.Equal(s, []int{1, 2, 3}) {
+			t.Errorf("%v ToSlice() = %v, want %v", name, s, []int{1, 2, 3})
+		}
+	}
+}
+
+func TestLSQueueOverflowPolicy(t *testing.T) {
+	evicted := make([]int, 0)
+	onEvict := WithEvictionCallback(func(x int) { evicted = append(evicted, x) })
+
+	tests := []struct {
+		policy     OverflowPolicy
+		want       []int
+		evicted    []int
+		tryErr     bool
+		tryEvicted []int
+	}{
+		{DropOldest, []int{3, 4, 5}, []int{0, 1, 2}, false, []int{3}},
+		{DropNewest, []int{0, 1, 2}, []int{3, 4, 5}, true, []int{6}},
+		{Reject, []int{0, 1, 2}, []int{3, 4, 5}, true, []int{}},
+		{Block, []int{0, 1, 2}, []int{3, 4, 5}, true, []int{}},
+	}
+
+	for _, tt := range tests {
+		evicted = evicted[:0]
+		queue := NewLSQueue(3, WithOverflowPolicy[int](tt.policy), onEvict)
+		for i := 0; i < 6; i++ {
+			queue.Enqueue(i)
+		}
+
+		if s := queue.ToSlice(); !slices.Equal(s, tt.want) {
+			t.Errorf("policy %v: ToSlice() = %v, want %v", tt.policy, s, tt.want)
+		}
+		if !slices.Equal(evicted, tt.evicted) {
+			t.Errorf("policy %v: evicted = %v, want %v", tt.policy, evicted, tt.evicted)
+		}
+
+		// Enqueue passes every discarded element to the callback, TryEnqueue hands a refused one back
+		// through the error and reports a dropped one through both
+		evicted = evicted[:0]
+		err := queue.TryEnqueue(6)
+		if (err != nil) != tt.tryErr || (err != nil && !errors.Is(err, ErrFull)) {
+			t.Errorf("policy %v: TryEnqueue() = %v, want error %v", tt.policy, err, tt.tryErr)
+		}
+		if !slices.Equal(evicted, tt.tryEvicted) {
+			t.Errorf("policy %v: evicted by TryEnqueue() = %v, want %v", tt.policy, evicted, tt.tryEvicted)
+		}
+	}
+}
+
+// Return a constructor for every queue implementing Fifo, keyed by name. Limited size queues have room
+// for 5000 elements
+func fifoFactories() map[string]func() Fifo[int] {
+	return map[string]func() Fifo[int]{
+		"Queue":        func() Fifo[int] { return NewQueue[int]() },
+		"LSQueue":      func() Fifo[int] { return NewLSQueue[int](5000) },
+		"SafeQueue":    func() Fifo[int] { return NewSafeQueue[int]() },
+		"SafeLSQueue":  func() Fifo[int] { return NewSafeLSQueue[int](5000) },
+		"Deque":        func() Fifo[int] { return NewDeque[int]() },
+		"BoundedQueue": func() Fifo[int] { return NewBoundedQueue[int](5000) },
+		"SPSCQueue":    func() Fifo[int] { return NewSPSCQueue[int](5000) },
+		"MPMCQueue":    func() Fifo[int] { return NewMPMCQueue[int]() },
+		"BoundedMPMC":  func() Fifo[int] { return NewBoundedMPMCQueue[int](5000) },
+		"Sharded":      func() Fifo[int] { return NewShardedQueue[int](4) },
+	}
+}
+
+func TestQueueIterators(t *testing.T) {
+	queues := fifoFactories()
+
+	// counts around the chunk boundaries, with a few elements dequeued up front so the
+	// iteration doesn't start at the beginning of a chunk
+	for name, newQueue := range queues {
+		for _, n := range []int{0, 1, 999, 1000, 1001, 2500} {
+			queue := newQueue()
+			for i := 0; i < n+3; i++ {
+				queue.Enqueue(i)
+			}
+			for i := 0; i < 3; i++ {
+				queue.Dequeue()
+			}
+
+			want := make([]int, 0, n)
+			for i := 3; i < n+3; i++ {
+				want = append(want, i)
+			}
+
+			if s := queue.ToSlice(); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) ToSlice() has %v elements, want %v", name, n, len(s), n)
+			}
+			if s := slices.Collect(queue.All()); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) All() has %v elements, want %v", name, n, len(s), n)
+			}
+
+			slices.Reverse(want)
+			if s := slices.Collect(queue.Backward()); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) Backward() has %v elements, want %v", name, n, len(s), n)
+			}
+
+			for i, v := range queue.Indexed() {
+				if v != i+3 {
+					t.Errorf("%v(%v) Indexed() = %v, %v, want %v, %v", name, n, i, v, i, i+3)
+					break
+				}
+			}
+
+			// stopping early
+			for v := range queue.All() {
+				if v != 3 {
+					t.Errorf("%v(%v) All() = %v, want %v", name, n, v, 3)
+				}
+				break
+			}
+		}
+	}
+}
+
+func TestQueueBatchDequeue(t *testing.T) {
+	queues := fifoFactories()
+
+	for name, newQueue := range queues {
+		if name == "Sharded" {
+			// batches are taken shard by shard, see TestShardedQueueBatchDequeue
+			continue
+		}
+		queue := newQueue()
+		for i := 0; i < 2500; i++ {
+			queue.Enqueue(i)
+		}
+
+		// a batch crossing a chunk boundary
+		s := queue.DequeueN(1200)
+		if len(s) != 1200 || s[0] != 0 || s[1199] != 1199 {
+			t.Errorf("%v DequeueN() has %v elements, want %v from %v to %v", name, len(s), 1200, 0, 1199)
+		}
+
+		dst := make([]int, 1000)
+		if n := queue.DequeueInto(dst); n != 1000 || dst[0] != 1200 || dst[999] != 2199 {
+			t.Errorf("%v DequeueInto() = %v, want %v from %v to %v", name, n, 1000, 1200, 2199)
+		}
+
+		// asking for more than is left
+		if n := queue.DequeueInto(dst); n != 300 || dst[299] != 2499 {
+			t.Errorf("%v DequeueInto() = %v, want %v", name, n, 300)
+		}
+		if s := queue.DequeueN(10); len(s) != 0 {
+			t.Errorf("%v DequeueN() = %v, want %v", name, s, []int{})
+		}
+
+		// the queue is still usable afterwards
+		for i := 0; i < 5; i++ {
+			queue.Enqueue(i)
+		}
+		drained := make([]int, 0)
+		for v := range queue.Drain() {
+			drained = append(drained, v)
+			if v == 2 {
+				break
+			}
+		}
+		if !slices.Equal(drained, []int{0, 1, 2}) || queue.Count() != 2 {
+			t.Errorf("%v Drain() = %v leaving %v, want %v leaving %v", name, drained, queue.Count(), []int{0, 1, 2}, 2)
+		}
+		if s := slices.Collect(queue.Drain()); !slices.Equal(s, []int{3, 4}) || !queue.IsEmpty() {
+			t.Errorf("%v Drain() = %v, want %v", name, s, []int{3, 4})
+		}
+	}
+}
+
+func TestQueueBatchEnqueue(t *testing.T) {
+	queues := fifoFactories()
+
+	want := make([]int, 0, 2501)
+	for i := 0; i < 2501; i++ {
+		want = append(want, i)
+	}
+
+	for name, newQueue := range queues {
+		queue := newQueue()
+		queue.Enqueue(0)
+		queue.EnqueueSlice(want[1:1000])
+		queue.EnqueueAll(want[1000:2000]...)
+		queue.EnqueueSlice(want[2000:])
+		queue.EnqueueAll()
+
+		if s := queue.ToSlice(); !slices.Equal(s, want) {
+			t.Errorf("%v ToSlice() has %v elements, want %v", name, len(s), len(want))
+		}
+
+		// single element operations carry on from a batch
+		queue.Enqueue(2501)
+		for i := 0; i <= 2501; i++ {
+			if element, _ := queue.Dequeue(); element != i {
+				t.Errorf("%v Dequeue() = %v, want %v", name, element, i)
+				break
+			}
+		}
+	}
+}
+
+func TestQueueChunkSize(t *testing.T) {
+	for _, size := range []int{1, 2, 7, 64} {
+		queues := map[string]Fifo[int]{
+			"Queue":     NewQueue[int](WithChunkSize(size)),
+			"SafeQueue": NewSafeQueue[int](WithChunkSize(size)),
+			"Deque":     NewDeque[int](WithChunkSize(size)),
+			"SafeDeque": NewSafeDeque[int](WithChunkSize(size)),
+		}
+
+		for name, queue := range queues {
+			want := make([]int, 0, 300)
+			for i := 0; i < 300; i++ {
+				want = append(want, i)
+			}
+
+			queue.Enqueue(0)
+			queue.EnqueueSlice(want[1:150])
+			for i := 150; i < 300; i++ {
+				queue.Enqueue(i)
+			}
+
+			if s := slices.Collect(queue.All()); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) All() has %v elements, want %v", name, size, len(s), len(want))
+			}
+			slices.Reverse(want)
+			if s := slices.Collect(queue.Backward()); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) Backward() has %v elements, want %v", name, size, len(s), len(want))
+			}
+
+			if s := queue.DequeueN(100); len(s) != 100 || s[99] != 99 {
+				t.Errorf("%v(%v) DequeueN() has %v elements, want %v", name, size, len(s), 100)
+			}
+			for i := 100; i < 300; i++ {
+				if element, _ := queue.Dequeue(); element != i {
+					t.Errorf("%v(%v) Dequeue() = %v, want %v", name, size, element, i)
+					break
+				}
+			}
 		}
 	}
 }
 
+func TestQueueChunkRecycling(t *testing.T) {
+	queues := map[string]Fifo[int]{
+		"Queue":     NewQueue[int](WithChunkSize(16)),
+		"SafeQueue": NewSafeQueue[int](WithChunkSize(16)),
+		"Deque":     NewDeque[int](WithChunkSize(16)),
+	}
+
+	for name, queue := range queues {
+		// steady traffic crossing chunk boundaries reuses the drained chunk
+		allocs := testing.AllocsPerRun(100, func() {
+			for i := 0; i < 16; i++ {
+				queue.Enqueue(i)
+			}
+			for i := 0; i < 16; i++ {
+				queue.Dequeue()
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("%v allocations per run = %v, want %v", name, allocs, 0)
+		}
+
+		queue.(Shrinker).Shrink()
+		queue.Enqueue(1)
+		if element, _ := queue.Dequeue(); element != 1 {
+			t.Errorf("%v Dequeue() = %v, want %v", name, element, 1)
+		}
+	}
+
+	// without spare chunks every boundary allocates
+	queue := NewQueue[int](WithChunkSize(16), WithSpareChunks(0))
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 16; i++ {
+			queue.Enqueue(i)
+		}
+		for i := 0; i < 16; i++ {
+			queue.Dequeue()
+		}
+	})
+	if allocs == 0 {
+		t.Errorf("allocations per run = %v, want more than %v", allocs, 0)
+	}
+}
+
+func TestSharedChunkPool(t *testing.T) {
+	a := NewQueue[int](WithChunkSize(8), WithSpareChunks(0), WithSharedChunkPool())
+	b := NewQueue[int](WithChunkSize(8), WithSpareChunks(0), WithSharedChunkPool())
+
+	if a.pool.shared == nil || a.pool.shared != b.pool.shared {
+		t.Errorf("shared pools = %p, %p, want the same pool", a.pool.shared, b.pool.shared)
+	}
+
+	// chunks returned to the shared pool come back cleared
+	for i := 0; i < 20; i++ {
+		a.Enqueue(i + 1)
+	}
+	for i := 0; i < 20; i++ {
+		a.Dequeue()
+	}
+	for i := 0; i < 20; i++ {
+		b.Enqueue(i)
+	}
+	for i := 0; i < 20; i++ {
+		if element, _ := b.Dequeue(); element != i {
+			t.Errorf("Dequeue() = %v, want %v", element, i)
+		}
+	}
+
+	other := NewQueue[int](WithChunkSize(16), WithSharedChunkPool())
+	if other.pool.shared == a.pool.shared {
+		t.Errorf("shared pools of different chunk sizes are the same")
+	}
+}
+
+// Return a copy of queue made with the Clone method of its concrete type
+func cloneFifo(queue Fifo[int]) Fifo[int] {
+	switch q := queue.(type) {
+	case *Queue[int]:
+		return q.Clone()
+	case *SafeQueue[int]:
+		return q.Clone()
+	case *LSQueue[int]:
+		return q.Clone()
+	case *SafeLSQueue[int]:
+		return q.Clone()
+	case *BoundedQueue[int]:
+		return q.Clone()
+	case *Deque[int]:
+		return q.Clone()
+	case *SafeDeque[int]:
+		return q.Clone()
+	}
+	return nil
+}
+
+func TestQueueClearClone(t *testing.T) {
+	queues := map[string]Fifo[int]{
+		"Queue":        NewQueue[int](WithChunkSize(4)),
+		"SafeQueue":    NewSafeQueue[int](WithChunkSize(4)),
+		"LSQueue":      NewLSQueue[int](16),
+		"SafeLSQueue":  NewSafeLSQueue[int](16),
+		"BoundedQueue": NewBoundedQueue[int](16),
+		"Deque":        NewDeque[int](WithChunkSize(4)),
+		"SafeDeque":    NewSafeDeque[int](WithChunkSize(4)),
+	}
+
+	for name, queue := range queues {
+		// move the front away from the start of the buffer before filling it
+		queue.EnqueueAll(-1, -2, -3)
+		queue.DequeueN(3)
+		queue.EnqueueAll(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+		clone := cloneFifo(queue)
+		if s := clone.ToSlice(); !slices.Equal(s, queue.ToSlice()) {
+			t.Errorf("%v Clone().ToSlice() = %v, want %v", name, s, queue.ToSlice())
+		}
+
+		// the clone and the original no longer share storage
+		clone.Dequeue()
+		clone.Enqueue(10)
+		if element, _ := queue.Peek(); element != 0 {
+			t.Errorf("%v Peek() after changing the clone = %v, want %v", name, element, 0)
+		}
+		if s := clone.ToSlice(); !slices.Equal(s, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+			t.Errorf("%v clone ToSlice() = %v, want %v", name, s, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+		}
+
+		queue.(interface{ Clear() }).Clear()
+		if !queue.IsEmpty() || queue.Count() != 0 {
+			t.Errorf("%v Count() after Clear() = %v, want %v", name, queue.Count(), 0)
+		}
+		if _, err := queue.Dequeue(); err == nil {
+			t.Errorf("%v Dequeue() after Clear() returned no error", name)
+		}
+		if clone.Count() != 10 {
+			t.Errorf("%v clone Count() after Clear() = %v, want %v", name, clone.Count(), 10)
+		}
+
+		// a cleared queue is ready for reuse
+		queue.EnqueueAll(0, 1, 2, 3, 4, 5)
+		if s := queue.ToSlice(); !slices.Equal(s, []int{0, 1, 2, 3, 4, 5}) {
+			t.Errorf("%v ToSlice() after Clear() = %v, want %v", name, s, []int{0, 1, 2, 3, 4, 5})
+		}
+	}
+}
+
+func TestLSQueueResize(t *testing.T) {
+	evicted := make([]int, 0)
+	queue := NewLSQueue(5, WithEvictionCallback(func(x int) { evicted = append(evicted, x) }))
+	queue.EnqueueAll(0, 1, 2, 3, 4, 5, 6)
+	evicted = evicted[:0]
+
+	// shrinking keeps the newest elements
+	queue.Resize(3)
+	if s := queue.ToSlice(); !slices.Equal(s, []int{4, 5, 6}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{4, 5, 6})
+	}
+	if !slices.Equal(evicted, []int{2, 3}) {
+		t.Errorf("evicted = %v, want %v", evicted, []int{2, 3})
+	}
+	if queue.Capacity() != 3 || !queue.IsFull() {
+		t.Errorf("Capacity() = %v, IsFull() = %v, want %v, %v", queue.Capacity(), queue.IsFull(), 3, true)
+	}
+
+	// growing keeps every element and makes room for more
+	queue.Resize(6)
+	queue.EnqueueAll(7, 8, 9, 10)
+	if s := queue.ToSlice(); !slices.Equal(s, []int{5, 6, 7, 8, 9, 10}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{5, 6, 7, 8, 9, 10})
+	}
+
+	safe := NewSafeLSQueue[int](4)
+	safe.Resize(2)
+	safe.EnqueueAll(0, 1, 2)
+	if s := safe.ToSlice(); !slices.Equal(s, []int{1, 2}) {
+		t.Errorf("SafeLSQueue ToSlice() = %v, want %v", s, []int{1, 2})
+	}
+
+	safe.Resize(0)
+	safe.Enqueue(3)
+	if !safe.IsEmpty() || safe.Capacity() != 0 {
+		t.Errorf("SafeLSQueue Count() = %v, Capacity() = %v, want %v, %v", safe.Count(), safe.Capacity(), 0, 0)
+	}
+}
+
+func TestQueueRandomAccess(t *testing.T) {
+	type randomAccessFifo interface {
+		Fifo[int]
+		At(i int) (int, error)
+		Set(i int, element int) error
+		PeekBack() (int, error)
+		IndexFunc(f func(int) bool) int
+		ContainsFunc(f func(int) bool) bool
+	}
+
+	queues := map[string]randomAccessFifo{
+		"Queue":        NewQueue[int](WithChunkSize(4)),
+		"SafeQueue":    NewSafeQueue[int](WithChunkSize(4)),
+		"LSQueue":      NewLSQueue[int](16),
+		"SafeLSQueue":  NewSafeLSQueue[int](16),
+		"BoundedQueue": NewBoundedQueue[int](16),
+		"Deque":        NewDeque[int](WithChunkSize(4)),
+		"SafeDeque":    NewSafeDeque[int](WithChunkSize(4)),
+	}
+
+	for name, queue := range queues {
+		if _, err := queue.At(0); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%v At(0) on an empty queue error = %v, want %v", name, err, ErrEmpty)
+		}
+		if _, err := queue.PeekBack(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%v PeekBack() on an empty queue error = %v, want %v", name, err, ErrEmpty)
+		}
+
+		// leave the tail exactly on a chunk boundary
+		queue.EnqueueAll(-1, -2, -3)
+		queue.DequeueN(3)
+		queue.EnqueueAll(0, 1, 2, 3, 4, 5, 6, 7, 8)
+
+		for i := 0; i < 9; i++ {
+			if element, err := queue.At(i); err != nil || element != i {
+				t.Errorf("%v At(%v) = %v, %v, want %v, %v", name, i, element, err, i, nil)
+			}
+		}
+		for _, i := range []int{-1, 9} {
+			if _, err := queue.At(i); !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("%v At(%v) error = %v, want %v", name, i, err, ErrOutOfRange)
+			}
+		}
+		if element, err := queue.PeekBack(); err != nil || element != 8 {
+			t.Errorf("%v PeekBack() = %v, %v, want %v, %v", name, element, err, 8, nil)
+		}
+
+		if err := queue.Set(5, 50); err != nil {
+			t.Errorf("%v Set(5) error = %v, want %v", name, err, nil)
+		}
+		if err := queue.Set(9, 90); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%v Set(9) error = %v, want %v", name, err, ErrOutOfRange)
+		}
+		if s := queue.ToSlice(); !slices.Equal(s, []int{0, 1, 2, 3, 4, 50, 6, 7, 8}) {
+			t.Errorf("%v ToSlice() = %v, want %v", name, s, []int{0, 1, 2, 3, 4, 50, 6, 7, 8})
+		}
+
+		if i := queue.IndexFunc(func(x int) bool { return x > 4 }); i != 5 {
+			t.Errorf("%v IndexFunc() = %v, want %v", name, i, 5)
+		}
+		if i := queue.IndexFunc(func(x int) bool { return x < 0 }); i != -1 {
+			t.Errorf("%v IndexFunc() = %v, want %v", name, i, -1)
+		}
+		if !queue.ContainsFunc(func(x int) bool { return x == 8 }) {
+			t.Errorf("%v ContainsFunc() = %v, want %v", name, false, true)
+		}
+	}
+}
+
+type payload struct {
+	buf [64]byte
+}
+
+// Return n payloads which increment freed once they are garbage collected
+func trackedPayloads(n int, freed *atomic.Int32) []*payload {
+	s := make([]*payload, n)
+	for i := range s {
+		s[i] = new(payload)
+		runtime.SetFinalizer(s[i], func(*payload) { freed.Add(1) })
+	}
+	return s
+}
+
+// Run the garbage collector until freed reaches want or it gives up
+func waitFreed(freed *atomic.Int32, want int32) int32 {
+	for i := 0; i < 50 && freed.Load() < want; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	return freed.Load()
+}
+
+func TestDequeuedSlotsReleased(t *testing.T) {
+	const n = 6
+
+	queues := map[string]func() Fifo[*payload]{
+		"Queue":        func() Fifo[*payload] { return NewQueue[*payload](WithChunkSize(16)) },
+		"SafeQueue":    func() Fifo[*payload] { return NewSafeQueue[*payload](WithChunkSize(16)) },
+		"LSQueue":      func() Fifo[*payload] { return NewLSQueue[*payload](16) },
+		"SafeLSQueue":  func() Fifo[*payload] { return NewSafeLSQueue[*payload](16) },
+		"BoundedQueue": func() Fifo[*payload] { return NewBoundedQueue[*payload](16) },
+		"Deque":        func() Fifo[*payload] { return NewDeque[*payload](WithChunkSize(16)) },
+		"SPSCQueue":    func() Fifo[*payload] { return NewSPSCQueue[*payload](16) },
+		"MPMCQueue":    func() Fifo[*payload] { return NewMPMCQueue[*payload]() },
+		"BoundedMPMC":  func() Fifo[*payload] { return NewBoundedMPMCQueue[*payload](16) },
+		// one shard, so the batch takes the payloads rather than the fillers
+		"Sharded": func() Fifo[*payload] { return NewShardedQueue[*payload](1) },
+	}
+
+	for name, newQueue := range queues {
+		var freed atomic.Int32
+		queue := newQueue()
+
+		// the fillers keep the chunk in use, so only cleared slots can release the payloads
+		queue.EnqueueSlice(trackedPayloads(n, &freed))
+		queue.EnqueueAll(new(payload), new(payload))
+
+		for i := 0; i < n/2; i++ {
+			queue.Dequeue()
+		}
+		queue.DequeueN(n / 2)
+
+		if got := waitFreed(&freed, n); got != n {
+			t.Errorf("%v payloads collected = %v, want %v", name, got, n)
+		}
+		runtime.KeepAlive(queue)
+	}
+
+	stacks := map[string]func() Lifo[*payload]{
+		"Stack":     func() Lifo[*payload] { return NewStack[*payload](WithChunkSize(16)) },
+		"SafeStack": func() Lifo[*payload] { return NewSafeStack[*payload](WithChunkSize(16)) },
+		"LockFree":  func() Lifo[*payload] { return NewLockFreeStack[*payload]() },
+	}
+
+	for name, newStack := range stacks {
+		var freed atomic.Int32
+		stack := newStack()
+
+		stack.PushAll(new(payload), new(payload))
+		for _, p := range trackedPayloads(n, &freed) {
+			stack.Push(p)
+		}
+
+		for i := 0; i < n/2; i++ {
+			stack.Pop()
+		}
+		stack.PopN(n / 2)
+
+		if got := waitFreed(&freed, n); got != n {
+			t.Errorf("%v payloads collected = %v, want %v", name, got, n)
+		}
+		runtime.KeepAlive(stack)
+	}
+
+	// popping from the back of a deque clears the slot as well
+	var freed atomic.Int32
+	deque := NewDeque[*payload](WithChunkSize(16))
+	deque.PushBack(new(payload))
+	for _, p := range trackedPayloads(n, &freed) {
+		deque.PushBack(p)
+	}
+	for i := 0; i < n; i++ {
+		deque.PopBack()
+	}
+	if got := waitFreed(&freed, n); got != n {
+		t.Errorf("Deque.PopBack payloads collected = %v, want %v", got, n)
+	}
+	runtime.KeepAlive(deque)
+}
+
 func BenchmarkRegularQueueEnqueue(b *testing.B) {
 	queue := NewQueue[int]()
 
@@ -180,3 +802,53 @@ func BenchmarkLimitedSizeQueueDequeue(b *testing.B) {
 		queue.Dequeue()
 	}
 }
+
+func BenchmarkQueueChunkSize(b *testing.B) {
+	// a small queue of large elements, where most of a chunk stays unused
+	type record struct {
+		payload [2048]byte
+	}
+
+	for _, size := range []int{16, 128, DefaultChunkSize} {
+		b.Run(fmt.Sprintf("small/%v", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				queue := NewQueue[record](WithChunkSize(size))
+				for j := 0; j < 10; j++ {
+					queue.Enqueue(record{})
+				}
+			}
+		})
+	}
+
+	// a long running queue of small elements, where the chunk size sets how often a chunk is allocated
+	for _, size := range []int{16, 128, DefaultChunkSize, 8192} {
+		b.Run(fmt.Sprintf("large/%v", size), func(b *testing.B) {
+			queue := NewQueue[int](WithChunkSize(size))
+			for i := 0; i < b.N; i++ {
+				queue.Enqueue(i)
+				if queue.Count() > 10000 {
+					queue.Dequeue()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkQueueSteadyState(b *testing.B) {
+	for _, spare := range []int{0, 1} {
+		b.Run(fmt.Sprintf("spare/%v", spare), func(b *testing.B) {
+			// a backlog keeps head and tail apart so both keep crossing chunk boundaries
+			queue := NewQueue[int](WithSpareChunks(spare))
+			for i := 0; i < 100; i++ {
+				queue.Enqueue(i)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				queue.Enqueue(i)
+				queue.Dequeue()
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package lists
+
+import (
+	"iter"
+	"sync"
+)
+
+// The SafeDeque is a double ended queue: a sequence of entities that can be added to and removed from both
+// the front and the back in O(1).
+//
+// SafeDeque is a thread safe version of Deque. However only the deque structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// SafeDeque is a list that implements the Fifo interface
+type SafeDeque[T any] struct {
+	deque *Deque[T]
+	mu    sync.RWMutex
+}
+
+// The constructor for a new SafeDeque instance with elements of type T.
+//
+// WithChunkSize changes the number of elements stored per chunk.
+//
+// Returns a pointer to a SafeDeque
+func NewSafeDeque[T any](opts ...ChunkOption) *SafeDeque[T] {
+	deque := NewDeque[T](opts...)
+	deque.kind = "SafeDeque"
+	return &SafeDeque[T]{
+		deque: deque,
+	}
+}
+
+// Return the number of elements in the deque. -1 means unlimited
+func (r *SafeDeque[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of elements in the deque
+func (r *SafeDeque[T]) Count() uint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deque.Count()
+}
+
+// Checks if the deque is empty
+//
+// Return true if empty false otherwise
+func (r *SafeDeque[T]) IsEmpty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deque.IsEmpty()
+}
+
+// Checks if the deque is full. Can never be full but just for interface implementation
+func (r *SafeDeque[T]) IsFull() bool {
+	return false
+}
+
+// Add an element of type T to the front of the deque. Complexity is O(1)
+func (r *SafeDeque[T]) PushFront(element T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deque.PushFront(element)
+}
+
+// Add an element of type T to the back of the deque. Complexity is O(1)
+func (r *SafeDeque[T]) PushBack(element T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deque.PushBack(element)
+}
+
+// Add elements of type T to the back of the deque in the given order.
+// The lock is taken once for the whole batch. Complexity is O(k)
+func (r *SafeDeque[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the back of the deque in order.
+// The lock is taken once for the whole batch. Complexity is O(k)
+func (r *SafeDeque[T]) EnqueueSlice(elements []T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deque.EnqueueSlice(elements)
+}
+
+// Remove and return the element of type T at the front of the deque. Complexity is O(1)
+func (r *SafeDeque[T]) PopFront() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deque.PopFront()
+}
+
+// Remove and return the element of type T at the back of the deque. Complexity is O(1)
+func (r *SafeDeque[T]) PopBack() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deque.PopBack()
+}
+
+// Remove up to len(dst) elements from the front of the deque and copy them into dst.
+// The lock is taken once for the whole batch. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *SafeDeque[T]) DequeueInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deque.DequeueInto(dst)
+}
+
+// Remove up to n elements from the front of the deque and return them.
+// The lock is taken once for the whole batch. Complexity is O(n)
+func (r *SafeDeque[T]) DequeueN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deque.DequeueN(n)
+}
+
+// Return an iterator which pops elements from the front until the deque is empty.
+// Breaking out of the loop leaves the remaining elements in the deque. Every element is popped under
+// its own lock so producers are not held up while the loop body runs; use DequeueN to take a batch at once.
+func (r *SafeDeque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.PopFront()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return the element of type T at the front of the deque without removing it. Complexity is O(1)
+func (r *SafeDeque[T]) PeekFront() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deque.PeekFront()
+}
+
+// Return the element of type T at the back of the deque without removing it. Complexity is O(1)
+func (r *SafeDeque[T]) PeekBack() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deque.PeekBack()
+}
+
+// Return the element of type T at position i counted from the front of the deque. Complexity is O(1)
+func (r *SafeDeque[T]) At(i int) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deque.At(i)
+}
+
+// Replace the element at position i counted from the front of the deque. Complexity is O(1)
+func (r *SafeDeque[T]) Set(i int, element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deque.Set(i, element)
+}
+
+// Add an element of type T to the back of the deque. Same as PushBack
+func (r *SafeDeque[T]) Enqueue(element T) {
+	r.PushBack(element)
+}
+
+// Remove and return the element of type T at the front of the deque. Same as PopFront
+func (r *SafeDeque[T]) Dequeue() (T, error) {
+	return r.PopFront()
+}
+
+// Return the element of type T at the front of the deque without removing it. Same as PeekFront
+func (r *SafeDeque[T]) Peek() (T, error) {
+	return r.PeekFront()
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// The read lock is held while searching, so f must not call methods of the deque. Complexity is O(n)
+func (r *SafeDeque[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the deque satisfies f. Complexity is O(n)
+func (r *SafeDeque[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the deque from front to back
+func (r *SafeDeque[T]) ToSlice() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deque.ToSlice()
+}
+
+// Return an iterator over the elements of the deque from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the deque.
+func (r *SafeDeque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.deque.All()(yield)
+	}
+}
+
+// Return an iterator over the elements of the deque from back to front.
+// The read lock is held while iterating, so the loop body must not call methods of the deque.
+func (r *SafeDeque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.deque.Backward()(yield)
+	}
+}
+
+// Return an iterator over the positions and elements of the deque from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the deque.
+func (r *SafeDeque[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.deque.Indexed()(yield)
+	}
+}
+
+// Remove all elements from the deque. One chunk is kept and the others are recycled, so a cleared
+// deque can be refilled without allocating. Complexity is O(n)
+func (r *SafeDeque[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deque.Clear()
+}
+
+// Return a copy of the deque which shares no chunks with it. The elements themselves are copied by value.
+// The read lock is held while copying. Complexity is O(n)
+func (r *SafeDeque[T]) Clone() *SafeDeque[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &SafeDeque[T]{
+		deque: r.deque.Clone(),
+	}
+}
+
+// Release the spare chunks kept for reuse, see WithSpareChunks
+func (r *SafeDeque[T]) Shrink() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deque.Shrink()
+}
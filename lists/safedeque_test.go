@@ -0,0 +1,26 @@
+package lists
+
+import (
+	"testing"
+)
+
+func BenchmarkSafeDequePushBack(b *testing.B) {
+	deque := NewSafeDeque[int]()
+
+	for i := 0; i < b.N; i++ {
+		deque.PushBack(i)
+	}
+}
+
+func BenchmarkSafeDequePopFront(b *testing.B) {
+	deque := NewSafeDeque[int]()
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		deque.PushBack(i)
+	}
+
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		deque.PopFront()
+	}
+}
@@ -0,0 +1,232 @@
+package lists
+
+import (
+	"iter"
+	"sync"
+)
+
+// The SafeList is a doubly linked list of elements of type T. Every insertion returns an Element
+// handle which can later be used to insert around, move or remove that entry in O(1).
+//
+// SafeList is a thread safe version of List. However only the list structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data. Element.Next and Element.Prev read the links
+// without locking, so concurrent traversal should go through SafeList.Next and SafeList.Prev instead.
+type SafeList[T any] struct {
+	list List[T]
+	mu   sync.RWMutex
+}
+
+// The constructor for a new SafeList instance with elements of type T.
+//
+// Returns a pointer to a SafeList
+func NewSafeList[T any]() *SafeList[T] {
+	r := &SafeList[T]{}
+	r.list.init()
+	return r
+}
+
+// Return the number of elements in the list
+func (r *SafeList[T]) Count() uint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.list.Count()
+}
+
+// Checks if the list is empty
+//
+// Return true if empty false otherwise
+func (r *SafeList[T]) IsEmpty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.list.IsEmpty()
+}
+
+// Return the first element of the list or nil if the list is empty
+func (r *SafeList[T]) Front() *Element[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.list.Front()
+}
+
+// Return the last element of the list or nil if the list is empty
+func (r *SafeList[T]) Back() *Element[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.list.Back()
+}
+
+// Return the element following e or nil if e is the last element or no longer in the list
+func (r *SafeList[T]) Next(e *Element[T]) *Element[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if e.list != &r.list {
+		return nil
+	}
+	return e.Next()
+}
+
+// Return the element preceding e or nil if e is the first element or no longer in the list
+func (r *SafeList[T]) Prev(e *Element[T]) *Element[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if e.list != &r.list {
+		return nil
+	}
+	return e.Prev()
+}
+
+// Insert a new element with the given value at the front of the list and return it. Complexity is O(1)
+func (r *SafeList[T]) PushFront(value T) *Element[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list.PushFront(value)
+}
+
+// Insert a new element with the given value at the back of the list and return it. Complexity is O(1)
+func (r *SafeList[T]) PushBack(value T) *Element[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list.PushBack(value)
+}
+
+// Insert a new element with the given value immediately before mark and return it.
+// If mark is not an element of this list, the list is not modified and nil is returned. Complexity is O(1)
+func (r *SafeList[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list.InsertBefore(value, mark)
+}
+
+// Insert a new element with the given value immediately after mark and return it.
+// If mark is not an element of this list, the list is not modified and nil is returned. Complexity is O(1)
+func (r *SafeList[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list.InsertAfter(value, mark)
+}
+
+// Remove e from the list if it is an element of this list and return its value. Complexity is O(1)
+func (r *SafeList[T]) Remove(e *Element[T]) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list.Remove(e)
+}
+
+// Move e to the front of the list. If e is not an element of this list, the list is not modified. Complexity is O(1)
+func (r *SafeList[T]) MoveToFront(e *Element[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list.MoveToFront(e)
+}
+
+// Move e to the back of the list. If e is not an element of this list, the list is not modified. Complexity is O(1)
+func (r *SafeList[T]) MoveToBack(e *Element[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list.MoveToBack(e)
+}
+
+// Move e immediately before mark. If e or mark is not an element of this list, or e == mark,
+// the list is not modified. Complexity is O(1)
+func (r *SafeList[T]) MoveBefore(e, mark *Element[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list.MoveBefore(e, mark)
+}
+
+// Move e immediately after mark. If e or mark is not an element of this list, or e == mark,
+// the list is not modified. Complexity is O(1)
+func (r *SafeList[T]) MoveAfter(e, mark *Element[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list.MoveAfter(e, mark)
+}
+
+// Remove all elements from the list. The removed elements are unlinked, so Element handles held elsewhere
+// no longer refer to the list. Complexity is O(n)
+func (r *SafeList[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list.Clear()
+}
+
+// Return a copy of the list with new elements holding the same values. The values themselves are copied
+// by value. The read lock is held while copying. Complexity is O(n)
+func (r *SafeList[T]) Clone() *SafeList[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := NewSafeList[T]()
+	for e := r.list.Front(); e != nil; e = e.Next() {
+		c.list.insertValue(e.Value, c.list.root.prev)
+	}
+	return c
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// The read lock is held while searching, so f must not call methods of the list. Complexity is O(n)
+func (r *SafeList[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the list satisfies f. Complexity is O(n)
+func (r *SafeList[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the list from front to back
+func (r *SafeList[T]) ToSlice() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.list.ToSlice()
+}
+
+// Return an iterator over the values of the list from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the list.
+func (r *SafeList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.list.All()(yield)
+	}
+}
+
+// Return an iterator over the values of the list from back to front.
+// The read lock is held while iterating, so the loop body must not call methods of the list.
+func (r *SafeList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.list.Backward()(yield)
+	}
+}
+
+// Return an iterator over the positions and values of the list from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the list.
+func (r *SafeList[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.list.Indexed()(yield)
+	}
+}
@@ -0,0 +1,26 @@
+package lists
+
+import (
+	"testing"
+)
+
+func BenchmarkSafeListPushBack(b *testing.B) {
+	list := NewSafeList[int]()
+
+	for i := 0; i < b.N; i++ {
+		list.PushBack(i)
+	}
+}
+
+func BenchmarkSafeListRemove(b *testing.B) {
+	list := NewSafeList[int]()
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		list.PushBack(i)
+	}
+
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		list.Remove(list.Front())
+	}
+}
@@ -1,38 +1,53 @@
 package lists
 
 import (
-	"errors"
+	"context"
+	"iter"
+	"slices"
 	"sync"
 )
 
 // The LSQueue is a collection of entities that are maintained in a sequence and can be modified
 // by the addition of entities at one end of the sequence and the removal of entities from the
 // other end of the sequence. This is a specialised version of queue that has a limited size.
-// When the limit is reached the first equeued element will be replaced by the next incoming enque.
+// When the limit is reached the first equeued element will be replaced by the next incoming enque,
+// unless a different OverflowPolicy was selected.
 //
 // SafeLSQueue is a thread safe version of LSQueue. However only the queue structure itself is safe. It is up to the
 // developer to ensure thread safety of the internals of the data.
 //
-// SafeLSQueue is a list that implements the Fifo interface
+// SafeLSQueue is a list that implements the Fifo interface. Closing the queue with Close lets producers
+// signal that no more elements will come, see Closable.
 type SafeLSQueue[T any] struct {
 	maxBuffSize uint
 	curBuffSize uint
 	lastIndex   int
 	data        []T
+	config      lsQueueConfig[T]
+	evicted     []T // discarded elements passed to the eviction callback once the lock is released
 	mu          sync.RWMutex
+	cond        *sync.Cond // signalled whenever an element is enqueued or the queue is closed
+	notFull     *sync.Cond // signalled whenever an element is dequeued or the queue is closed
+	closer      closer
 }
 
 // The constructor for a new LSQueue instance with elements of type T.
+// Options such as WithOverflowPolicy and WithEvictionCallback change what happens once the queue is full.
 //
 // # Returns a pointer to a LSQueue
-func NewSafeLSQueue[T any](size uint) Fifo[T] {
+func NewSafeLSQueue[T any](size uint, opts ...LSQueueOption[T]) *SafeLSQueue[T] {
 
-	return &SafeLSQueue[T]{
+	r := &SafeLSQueue[T]{
 		maxBuffSize: size,
 		curBuffSize: 0,
 		lastIndex:   0,
 		data:        make([]T, size),
+		config:      newLSQueueConfig(opts),
+		closer:      newCloser(),
 	}
+	r.cond = sync.NewCond(&r.mu)
+	r.notFull = sync.NewCond(&r.mu)
+	return r
 }
 
 // A hidden method to compute the index of the next element to be dequeued
@@ -50,21 +65,147 @@ func (r *SafeLSQueue[T]) getFrontElementIndex() int {
 	return i
 }
 
-// Add an element of type T to the end of the queue. Complexity is O(1)
-func (r *SafeLSQueue[T]) Enqueue(element T) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// A hidden method which reports whether the queue has room for an element. Must be called with the lock held
+func (r *SafeLSQueue[T]) hasRoom() bool {
+	return r.curBuffSize < r.maxBuffSize
+}
 
-	if r.maxBuffSize == 0 {
-		return
+// A hidden method which keeps a discarded element for the eviction callback, if any. Must be called with
+// the lock held
+func (r *SafeLSQueue[T]) evict(element T) {
+	if r.config.onEvict != nil {
+		r.evicted = append(r.evicted, element)
 	}
+}
 
-	if r.curBuffSize+1 < r.maxBuffSize {
+// A hidden method which releases the lock and then passes the elements discarded meanwhile to the eviction
+// callback, so the callback may use the queue
+func (r *SafeLSQueue[T]) unlock() {
+	evicted := r.evicted
+	r.evicted = nil
+	r.mu.Unlock()
+
+	for _, element := range evicted {
+		r.config.evict(element)
+	}
+}
+
+// A hidden method which stores element if there is room for it or the policy is DropOldest.
+// Must be called with the lock held
+//
+// Returns false if the element was not stored
+func (r *SafeLSQueue[T]) offer(element T) bool {
+	if r.curBuffSize == r.maxBuffSize {
+		if r.config.policy != DropOldest || r.maxBuffSize == 0 {
+			return false
+		}
+		r.evict(r.data[r.getFrontElementIndex()])
+	} else {
 		r.curBuffSize++
 	}
 
 	r.lastIndex = (r.lastIndex + 1) % int(r.maxBuffSize)
 	r.data[r.lastIndex] = element
+	r.cond.Broadcast()
+	return true
+}
+
+// Add an element of type T to the end of the queue. Complexity is O(1)
+//
+// If the queue is full the overflow policy decides which element is discarded. With the Block policy
+// Enqueue waits until a consumer or Resize makes room instead, and discards the element if the queue has
+// size 0, even when resized to 0 while waiting. Use EnqueueCtx to give up waiting. The eviction callback
+// is called after the lock is released, so it may use the queue.
+//
+// Panics with an error wrapping ErrClosed if the queue is closed, like a send on a closed channel, and so
+// does a producer waiting for room under the Block policy when the queue gets closed. Use TryEnqueue or
+// EnqueueCtx where the queue may be closed concurrently
+func (r *SafeLSQueue[T]) Enqueue(element T) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	if err := r.enqueue(context.Background(), "Enqueue", element); err != nil {
+		panic(err)
+	}
+}
+
+// Add an element of type T to the end of the queue like Enqueue, but give up waiting for room under the
+// Block policy once ctx is done. Complexity is O(1)
+//
+// Returns an error wrapping ErrClosed if the queue is or gets closed, or wrapping the context error if ctx
+// is done before there was room. The element is then neither added nor passed to the eviction callback
+func (r *SafeLSQueue[T]) EnqueueCtx(ctx context.Context, element T) error {
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.enqueue(ctx, "EnqueueCtx", element)
+}
+
+// Add elements of type T to the end of the queue in the given order, applying the overflow policy
+// to each one. The lock is taken once for the whole batch, except while waiting under the Block policy.
+// Panics like Enqueue if the queue is or gets closed. Complexity is O(k)
+func (r *SafeLSQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order, applying the overflow policy
+// to each one. The lock is taken once for the whole batch, except while waiting under the Block policy.
+// Panics like Enqueue if the queue is or gets closed, keeping the elements added before. Complexity is O(k)
+func (r *SafeLSQueue[T]) EnqueueSlice(elements []T) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	for _, element := range elements {
+		if err := r.enqueue(context.Background(), "EnqueueSlice", element); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// A hidden method which enqueues an element according to the overflow policy unless the queue is closed,
+// waiting for room under the Block policy until ctx is done. Must be called with the lock held
+//
+// Returns an error if the queue is closed or ctx is done before there was room
+func (r *SafeLSQueue[T]) enqueue(ctx context.Context, op string, element T) error {
+	if r.config.policy == Block {
+		// a queue resized to 0 while waiting can never make room, the element is discarded instead
+		ready := func() bool {
+			return r.maxBuffSize == 0 || r.hasRoom() || r.closer.isClosed()
+		}
+		if err := waitFor(ctx, "SafeLSQueue", op, r.notFull, ready); err != nil {
+			return err
+		}
+	}
+	if r.closer.isClosed() {
+		return newContainerError("SafeLSQueue", op, ErrClosed)
+	}
+
+	if !r.offer(element) {
+		r.evict(element)
+	}
+	return nil
+}
+
+// Add an element of type T to the end of the queue without blocking. Complexity is O(1)
+//
+// Returns an error if the queue is closed, or wrapping ErrFull if it is full and the element was not
+// added. Under DropNewest the element is passed to the eviction callback as well, under Reject and Block
+// it is not
+func (r *SafeLSQueue[T]) TryEnqueue(element T) error {
+	r.mu.Lock()
+	defer r.unlock()
+
+	if r.closer.isClosed() {
+		return newContainerError("SafeLSQueue", "TryEnqueue", ErrClosed)
+	}
+
+	if !r.offer(element) {
+		if r.config.dropsRefused() {
+			r.evict(element)
+		}
+		return newContainerError("SafeLSQueue", "TryEnqueue", ErrFull)
+	}
+	return nil
 }
 
 // Remove and return am element of type T from the beginning of the queue. Complexity is O(1)
@@ -72,15 +213,108 @@ func (r *SafeLSQueue[T]) Dequeue() (T, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.dequeue()
+}
+
+// Remove and return an element of type T from the beginning of the queue, blocking until one is available
+// or ctx is done. Complexity is O(1)
+//
+// Returns an error wrapping ErrClosed if the queue is closed and empty
+func (r *SafeLSQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.wait(ctx, "DequeueWait"); err != nil {
+		var result T
+		return result, err
+	}
+	return r.dequeue()
+}
+
+// A hidden method which blocks until the queue holds elements, ctx is done or the queue is closed and
+// empty. Must be called with the lock held
+func (r *SafeLSQueue[T]) wait(ctx context.Context, op string) error {
+	ready := func() bool {
+		return r.curBuffSize > 0 || r.closer.isClosed()
+	}
+	if err := waitFor(ctx, "SafeLSQueue", op, r.cond, ready); err != nil {
+		return err
+	}
+	if r.curBuffSize == 0 {
+		return newContainerError("SafeLSQueue", op, ErrClosed)
+	}
+	return nil
+}
+
+// A hidden method which dequeues an element. Must be called with the lock held
+func (r *SafeLSQueue[T]) dequeue() (T, error) {
 	if r.curBuffSize == 0 {
 		var result T
-		return result, errors.New("empty list")
+		return result, newContainerError("SafeLSQueue", "Dequeue", ErrEmpty)
 	}
 
 	indexOfElementToDequeue := r.getFrontElementIndex()
+	result := r.data[indexOfElementToDequeue]
+	var zero T
+	r.data[indexOfElementToDequeue] = zero // don't keep the value reachable
 
 	r.curBuffSize--
-	return r.data[indexOfElementToDequeue], nil
+	r.notFull.Broadcast()
+	return result, nil
+}
+
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// The lock is taken once for the whole batch. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *SafeLSQueue[T]) DequeueInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.dequeueInto(dst)
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order.
+// The lock is taken once for the whole batch. Complexity is O(n)
+func (r *SafeLSQueue[T]) DequeueN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.dequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue. Every element is dequeued under
+// its own lock so producers are not held up while the loop body runs; use DequeueN to take a batch at once.
+func (r *SafeLSQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// A hidden method which dequeues up to len(dst) elements into dst. Must be called with the lock held
+func (r *SafeLSQueue[T]) dequeueInto(dst []T) int {
+	n := min(len(dst), int(r.curBuffSize))
+	front := r.getFrontElementIndex()
+	var zero T
+
+	for i := 0; i < n; i++ {
+		j := (front + i) % int(r.maxBuffSize)
+		dst[i] = r.data[j]
+		r.data[j] = zero
+	}
+
+	r.curBuffSize -= uint(n)
+	if n > 0 {
+		r.notFull.Broadcast()
+	}
+	return n
 }
 
 // Checks if the queue is empty
@@ -95,7 +329,6 @@ func (r *SafeLSQueue[T]) IsEmpty() bool {
 // Checks if the limited size queue is full
 //
 // Return true if the limites size queue has reached its given capacity. Otherwise returns false.
-// Also returns false if the queue was created with a size of 0 (generic unlimited queue)
 func (r *SafeLSQueue[T]) IsFull() bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -109,13 +342,81 @@ func (r *SafeLSQueue[T]) Peek() (T, error) {
 
 	if r.curBuffSize == 0 {
 		var result T
-		return result, errors.New("empty list")
+		return result, newContainerError("SafeLSQueue", "Peek", ErrEmpty)
+	}
+
+	indexOfElementToDequeue := r.getFrontElementIndex()
+	return r.data[indexOfElementToDequeue], nil
+}
+
+// Return an element of type T from the beginning of the queue without Dequeuing it, blocking until one is
+// available or ctx is done. Complexity is O(1)
+//
+// Returns an error wrapping ErrClosed if the queue is closed and empty
+func (r *SafeLSQueue[T]) PeekWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.wait(ctx, "PeekWait"); err != nil {
+		var result T
+		return result, err
 	}
 
 	indexOfElementToDequeue := r.getFrontElementIndex()
 	return r.data[indexOfElementToDequeue], nil
 }
 
+// Return the element of type T at position i counted from the front of the queue, where 0 is the element
+// Dequeue would return. Complexity is O(1)
+func (r *SafeLSQueue[T]) At(i int) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if err := checkIndex("SafeLSQueue", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	return r.data[(r.getFrontElementIndex()+i)%int(r.maxBuffSize)], nil
+}
+
+// Replace the element at position i counted from the front of the queue. Complexity is O(1)
+func (r *SafeLSQueue[T]) Set(i int, element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := checkIndex("SafeLSQueue", "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	r.data[(r.getFrontElementIndex()+i)%int(r.maxBuffSize)] = element
+	return nil
+}
+
+// Return the most recently enqueued element of type T without Dequeuing it. Complexity is O(1)
+func (r *SafeLSQueue[T]) PeekBack() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if r.curBuffSize == 0 {
+		return result, newContainerError("SafeLSQueue", "PeekBack", ErrEmpty)
+	}
+
+	return r.data[r.lastIndex], nil
+}
+
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// The read lock is held while searching, so f must not call methods of the queue. Complexity is O(n)
+func (r *SafeLSQueue[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the queue satisfies f. Complexity is O(n)
+func (r *SafeLSQueue[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
 // Return a slice representation of the current state of the queue
 func (r *SafeLSQueue[T]) ToSlice() []T {
 	r.mu.RLock()
@@ -137,6 +438,39 @@ func (r *SafeLSQueue[T]) ToSlice() []T {
 	return s
 }
 
+// Return an iterator over the elements of the queue from front to back, in Dequeue order.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafeLSQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkRing(r.data, r.getFrontElementIndex(), int(r.curBuffSize), func(_ int, v T) bool { return yield(v) })
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafeLSQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkRingBackward(r.data, r.getFrontElementIndex(), int(r.curBuffSize), yield)
+	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafeLSQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkRing(r.data, r.getFrontElementIndex(), int(r.curBuffSize), yield)
+	}
+}
+
 // Return the number of elements in the queue
 func (r *SafeLSQueue[T]) Count() uint {
 	r.mu.RLock()
@@ -152,3 +486,87 @@ func (r *SafeLSQueue[T]) Capacity() int {
 
 	return int(r.maxBuffSize)
 }
+
+// Remove all elements from the queue. The buffer is kept and cleared, so a cleared queue can be refilled
+// without allocating. Complexity is O(n)
+func (r *SafeLSQueue[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.data)
+	r.curBuffSize = 0
+	r.lastIndex = 0
+	r.notFull.Broadcast()
+}
+
+// Return a copy of the queue with its own buffer and the same overflow settings. The elements themselves
+// are copied by value. The copy is open even if the queue was closed. The read lock is held while copying.
+// Complexity is O(n)
+func (r *SafeLSQueue[T]) Clone() *SafeLSQueue[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := &SafeLSQueue[T]{
+		maxBuffSize: r.maxBuffSize,
+		curBuffSize: r.curBuffSize,
+		lastIndex:   r.lastIndex,
+		data:        slices.Clone(r.data),
+		config:      r.config,
+		closer:      newCloser(),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	c.notFull = sync.NewCond(&c.mu)
+	return c
+}
+
+// Change the size of the queue to n elements. If the queue holds more than n elements the newest ones are
+// kept and the oldest are passed to the eviction callback. Complexity is O(n)
+func (r *SafeLSQueue[T]) Resize(n uint) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	data := make([]T, n)
+	drop := r.curBuffSize - min(r.curBuffSize, n)
+
+	walkRing(r.data, r.getFrontElementIndex(), int(r.curBuffSize), func(i int, v T) bool {
+		if uint(i) < drop {
+			r.evict(v)
+		} else {
+			data[uint(i)-drop] = v
+		}
+		return true
+	})
+
+	r.data = data
+	r.maxBuffSize = n
+	r.curBuffSize -= drop
+	r.lastIndex = int(r.curBuffSize) - 1
+	r.notFull.Broadcast()
+}
+
+// Close the queue. Further elements make Enqueue panic and are refused by TryEnqueue and EnqueueCtx, while
+// the elements left can still be dequeued. Every goroutine blocked in DequeueWait or PeekWait wakes up and
+// returns ErrClosed once the queue is empty, and producers waiting for room under the Block policy give up
+// their elements, panicking in Enqueue and returning ErrClosed from EnqueueCtx.
+// Closing a closed queue does nothing
+func (r *SafeLSQueue[T]) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.close() {
+		r.cond.Broadcast()
+		r.notFull.Broadcast()
+	}
+}
+
+// Checks if the queue was closed
+//
+// Return true if closed false otherwise
+func (r *SafeLSQueue[T]) IsClosed() bool {
+	return r.closer.isClosed()
+}
+
+// Return a channel which is closed when the queue is closed, for use in select statements
+func (r *SafeLSQueue[T]) Done() <-chan struct{} {
+	return r.closer.done
+}
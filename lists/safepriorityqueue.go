@@ -0,0 +1,279 @@
+package lists
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"sync"
+)
+
+// The SafePriorityQueue is a collection of entities which are dequeued in order of priority rather than in
+// the order they were enqueued. Elements of equal priority are dequeued in the order they were enqueued.
+//
+// SafePriorityQueue is a thread safe version of PriorityQueue. However only the queue structure itself is safe.
+// It is up to the developer to ensure thread safety of the internals of the data. In particular the Value of a
+// PriorityElement should only be changed through SafePriorityQueue.Update.
+//
+// SafePriorityQueue is a list that implements the BlockingFifo interface
+type SafePriorityQueue[T any] struct {
+	queue *PriorityQueue[T]
+	mu    sync.RWMutex
+	cond  *sync.Cond // signalled whenever an element is enqueued
+}
+
+// The constructor for a new SafePriorityQueue instance with elements of type T, where less(a, b) reports
+// whether a must be dequeued before b.
+//
+// Returns a pointer to a SafePriorityQueue
+func NewSafePriorityQueue[T any](less func(a, b T) bool) *SafePriorityQueue[T] {
+	r := &SafePriorityQueue[T]{
+		queue: NewPriorityQueue(less),
+	}
+	r.queue.kind = "SafePriorityQueue"
+	r.cond = sync.NewCond(&r.mu)
+	return r
+}
+
+// The constructor for a new SafePriorityQueue instance with ordered elements of type T, which dequeues the
+// smallest element first.
+//
+// Returns a pointer to a SafePriorityQueue
+func NewSafeOrderedPriorityQueue[T cmp.Ordered]() *SafePriorityQueue[T] {
+	return NewSafePriorityQueue(cmp.Less[T])
+}
+
+// A hidden method which reports whether the queue holds elements. Must be called with the lock held
+func (r *SafePriorityQueue[T]) hasElements() bool {
+	return len(r.queue.heap) > 0
+}
+
+// Return the number of elements in the queue. -1 means unlimited
+func (r *SafePriorityQueue[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of elements in the queue
+func (r *SafePriorityQueue[T]) Count() uint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.queue.Count()
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *SafePriorityQueue[T]) IsEmpty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.queue.IsEmpty()
+}
+
+// Checks if the queue is full. Can never be full but just for interface implementation
+func (r *SafePriorityQueue[T]) IsFull() bool {
+	return false
+}
+
+// Add a new element with the given value to the queue and return it. Complexity is O(log n)
+func (r *SafePriorityQueue[T]) Push(value T) *PriorityElement[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := r.queue.Push(value)
+	r.cond.Broadcast()
+	return e
+}
+
+// Add an element of type T to the queue. Same as Push without returning the handle
+func (r *SafePriorityQueue[T]) Enqueue(element T) {
+	r.Push(element)
+}
+
+// Add elements of type T to the queue. Elements of equal priority keep the given order.
+// The lock is taken once for the whole batch. Complexity is O(k log n)
+func (r *SafePriorityQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the queue. Elements of equal priority keep the given order.
+// The lock is taken once for the whole batch. Complexity is O(min(k log n, n + k))
+func (r *SafePriorityQueue[T]) EnqueueSlice(elements []T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queue.EnqueueSlice(elements)
+	if len(elements) > 0 {
+		r.cond.Broadcast()
+	}
+}
+
+// Remove and return the element of type T with the highest priority. Complexity is O(log n)
+func (r *SafePriorityQueue[T]) Dequeue() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.queue.Dequeue()
+}
+
+// Remove and return the element of type T with the highest priority, blocking until one is available
+// or ctx is done. Complexity is O(log n)
+func (r *SafePriorityQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := waitFor(ctx, "SafePriorityQueue", "DequeueWait", r.cond, r.hasElements); err != nil {
+		var result T
+		return result, err
+	}
+
+	return r.queue.Dequeue()
+}
+
+// Remove up to len(dst) elements in order of priority and copy them into dst.
+// The lock is taken once for the whole batch. Complexity is O(k log n)
+//
+// Returns the number of elements copied
+func (r *SafePriorityQueue[T]) DequeueInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.queue.DequeueInto(dst)
+}
+
+// Remove up to n elements in order of priority and return them.
+// The lock is taken once for the whole batch. Complexity is O(n log n)
+func (r *SafePriorityQueue[T]) DequeueN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.queue.DequeueN(n)
+}
+
+// Return an iterator which dequeues elements in order of priority until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue. Every element is dequeued under
+// its own lock so producers are not held up while the loop body runs; use DequeueN to take a batch at once.
+func (r *SafePriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return the element of type T with the highest priority without Dequeuing it. Complexity is O(1)
+func (r *SafePriorityQueue[T]) Peek() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.queue.Peek()
+}
+
+// Return the element of type T with the highest priority without Dequeuing it, blocking until one is
+// available or ctx is done. Complexity is O(1)
+func (r *SafePriorityQueue[T]) PeekWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := waitFor(ctx, "SafePriorityQueue", "PeekWait", r.cond, r.hasElements); err != nil {
+		var result T
+		return result, err
+	}
+
+	return r.queue.Peek()
+}
+
+// Restore the order of the queue after the Value of e was changed in place. If e is not an element of
+// this queue, the queue is not modified. Complexity is O(log n)
+func (r *SafePriorityQueue[T]) Fix(e *PriorityElement[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queue.Fix(e)
+}
+
+// Change the Value of e and move it to its new place in the queue. If e is not an element of this queue,
+// the queue is not modified. Complexity is O(log n)
+func (r *SafePriorityQueue[T]) Update(e *PriorityElement[T], value T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queue.Update(e, value)
+}
+
+// Remove e from the queue if it is an element of this queue and return its value. Complexity is O(log n)
+func (r *SafePriorityQueue[T]) Remove(e *PriorityElement[T]) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.queue.Remove(e)
+}
+
+// Remove all elements from the queue. The removed elements are unlinked, so PriorityElement handles
+// held elsewhere no longer refer to the queue. Complexity is O(n)
+func (r *SafePriorityQueue[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queue.Clear()
+}
+
+// Return a copy of the queue with new elements holding the same values. The values themselves are copied
+// by value. The read lock is held while copying. Complexity is O(n)
+func (r *SafePriorityQueue[T]) Clone() *SafePriorityQueue[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := &SafePriorityQueue[T]{
+		queue: r.queue.Clone(),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Return a slice representation of the current state of the queue in Dequeue order. Complexity is O(n log n)
+func (r *SafePriorityQueue[T]) ToSlice() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.queue.ToSlice()
+}
+
+// Return an iterator over the elements of the queue in Dequeue order. The elements are sorted up front,
+// so the iteration costs O(n log n).
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafePriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.queue.All()(yield)
+	}
+}
+
+// Return an iterator over the elements of the queue in reverse Dequeue order, lowest priority first.
+// The elements are sorted up front, so the iteration costs O(n log n).
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafePriorityQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.queue.Backward()(yield)
+	}
+}
+
+// Return an iterator over the positions and elements of the queue in Dequeue order. The elements are
+// sorted up front, so the iteration costs O(n log n).
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafePriorityQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		r.queue.Indexed()(yield)
+	}
+}
@@ -1,7 +1,8 @@
 package lists
 
 import (
-	"errors"
+	"context"
+	"iter"
 	"sync"
 )
 
@@ -12,30 +13,43 @@ import (
 // SafeQueue is a thread safe version of Queue. However only the queue structure itself is safe. It is up to the
 // developer to ensure thread safety of the internals of the data.
 //
-// SafeQueue is a list that implements the Fifo interface
+// SafeQueue is a list that implements the Fifo interface. Closing the queue with Close lets producers
+// signal that no more elements will come, see Closable.
 type SafeQueue[T any] struct {
 	curBuffSize uint
 	headIndex   uint
 	tailIndex   uint
+	chunkSize   uint
+	pool        chunkPool[T]
 	head        *arrnode[T]
 	tail        *arrnode[T]
 	mu          sync.RWMutex
+	cond        *sync.Cond // signalled whenever an element is enqueued or the queue is closed
+	closer      closer
 }
 
 // The constructor for a new Queue instance with elements of type T.
 // If size > 0 then the generated queue is a limited size queue.
 // If size = 0 then it is a generic unlimited queue
 //
+// WithChunkSize changes the number of elements stored per chunk.
+//
 // Returns a pointer to a queue
-func NewSafeQueue[T any]() Fifo[T] {
-	node := newArrayNode[T](nil)
-	return &SafeQueue[T]{
+func NewSafeQueue[T any](opts ...ChunkOption) *SafeQueue[T] {
+	config := newChunkConfig(opts)
+	node := newArrayNode[T](nil, config.chunkSize)
+	r := &SafeQueue[T]{
 		curBuffSize: 0,
 		headIndex:   0,
 		tailIndex:   0,
+		chunkSize:   config.chunkSize,
+		pool:        newChunkPool[T](config),
 		head:        node,
 		tail:        node,
+		closer:      newCloser(),
 	}
+	r.cond = sync.NewCond(&r.mu)
+	return r
 }
 
 // Return the number of elements in the queue. -1 means unlimited
@@ -44,15 +58,40 @@ func (r *SafeQueue[T]) Capacity() int {
 }
 
 // Add an element of type T to the end of the queue. Complexity is O(1)
+//
+// Panics with an error wrapping ErrClosed if the queue is closed, like a send on a closed channel.
+// Use TryEnqueue where the queue may be closed concurrently
 func (r *SafeQueue[T]) Enqueue(element T) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.closer.isClosed() {
+		panic(newContainerError("SafeQueue", "Enqueue", ErrClosed))
+	}
+	r.enqueue(element)
+}
+
+// Add an element of type T to the end of the queue. Complexity is O(1)
+//
+// Returns an error if the queue is closed
+func (r *SafeQueue[T]) TryEnqueue(element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.isClosed() {
+		return newContainerError("SafeQueue", "TryEnqueue", ErrClosed)
+	}
+	r.enqueue(element)
+	return nil
+}
+
+// A hidden method which enqueues an element. Must be called with the lock held
+func (r *SafeQueue[T]) enqueue(element T) {
 	r.tail.write(element, int(r.tailIndex))
 
-	if r.tailIndex == 999 {
-		// prepare a new tail node for extra 1000 entries
-		node := newArrayNode[T](nil)
+	if r.tailIndex == r.chunkSize-1 {
+		// prepare a new tail node for another chunk of entries
+		node := r.pool.get(nil)
 		r.tail.next = node
 		r.tail = r.tail.next
 		r.tailIndex = 0
@@ -60,6 +99,45 @@ func (r *SafeQueue[T]) Enqueue(element T) {
 		r.tailIndex++
 	}
 	r.curBuffSize++
+	r.cond.Broadcast()
+}
+
+// Add elements of type T to the end of the queue in the given order.
+// The lock is taken once for the whole batch. Panics like Enqueue if the queue is closed. Complexity is O(k)
+func (r *SafeQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order. Elements are copied into the tail node
+// a whole run at a time and the lock is taken once for the whole batch. Panics like Enqueue if the queue
+// is closed, without adding any element. Complexity is O(k)
+func (r *SafeQueue[T]) EnqueueSlice(elements []T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.isClosed() {
+		panic(newContainerError("SafeQueue", "EnqueueSlice", ErrClosed))
+	}
+
+	for len(elements) > 0 {
+		k := copy(r.tail.data[r.tailIndex:], elements)
+		elements = elements[k:]
+		r.tailIndex += uint(k)
+		r.curBuffSize += uint(k)
+
+		if r.tailIndex == r.chunkSize {
+			// prepare a new tail node for another chunk of entries
+			node := r.pool.get(nil)
+			r.tail.next = node
+			r.tail = r.tail.next
+			r.tailIndex = 0
+		}
+	}
+	r.cond.Broadcast()
 }
 
 // Remove and return am element of type T from the beginning of the queue. Complexity is O(1)
@@ -67,17 +145,54 @@ func (r *SafeQueue[T]) Dequeue() (T, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.dequeue()
+}
+
+// Remove and return an element of type T from the beginning of the queue, blocking until one is available
+// or ctx is done. Complexity is O(1)
+//
+// Returns an error wrapping ErrClosed if the queue is closed and empty
+func (r *SafeQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.wait(ctx, "DequeueWait"); err != nil {
+		var result T
+		return result, err
+	}
+	return r.dequeue()
+}
+
+// A hidden method which blocks until the queue holds elements, ctx is done or the queue is closed and
+// empty. Must be called with the lock held
+func (r *SafeQueue[T]) wait(ctx context.Context, op string) error {
+	ready := func() bool {
+		return r.curBuffSize > 0 || r.closer.isClosed()
+	}
+	if err := waitFor(ctx, "SafeQueue", op, r.cond, ready); err != nil {
+		return err
+	}
+	if r.curBuffSize == 0 {
+		return newContainerError("SafeQueue", op, ErrClosed)
+	}
+	return nil
+}
+
+// A hidden method which dequeues an element. Must be called with the lock held
+func (r *SafeQueue[T]) dequeue() (T, error) {
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("SafeQueue", "Dequeue", ErrEmpty)
 	}
 
 	r.curBuffSize--
-	result = r.head.read(int(r.headIndex))
+	result = r.head.take(int(r.headIndex))
 
-	if r.headIndex == 999 {
+	if r.headIndex == r.chunkSize-1 {
 		r.headIndex = 0
+		node := r.head
 		r.head = r.head.next
+		r.pool.put(node)
 	} else {
 		r.headIndex++
 	}
@@ -90,6 +205,70 @@ func (r *SafeQueue[T]) Dequeue() (T, error) {
 	return result, nil
 }
 
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// The lock is taken once for the whole batch. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *SafeQueue[T]) DequeueInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.dequeueInto(dst)
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order.
+// The lock is taken once for the whole batch. Complexity is O(n)
+func (r *SafeQueue[T]) DequeueN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.dequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue. Every element is dequeued under
+// its own lock so producers are not held up while the loop body runs; use DequeueN to take a batch at once.
+func (r *SafeQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// A hidden method which dequeues up to len(dst) elements into dst. Must be called with the lock held
+func (r *SafeQueue[T]) dequeueInto(dst []T) int {
+	n := 0
+	for n < len(dst) && r.curBuffSize > 0 {
+		// copy the run of elements left in the head node in one go
+		end := min(r.chunkSize, r.headIndex+r.curBuffSize)
+		k := uint(copy(dst[n:], r.head.data[r.headIndex:end]))
+		clear(r.head.data[r.headIndex : r.headIndex+k])
+
+		n += int(k)
+		r.curBuffSize -= k
+		r.headIndex += k
+
+		if r.headIndex == r.chunkSize {
+			r.headIndex = 0
+			node := r.head
+			r.head = r.head.next
+			r.pool.put(node)
+		}
+	}
+
+	if r.curBuffSize == 0 {
+		r.headIndex = 0
+		r.tailIndex = 0
+	}
+
+	return n
+}
+
 // Checks if the queue is empty
 //
 // Return true if empty false otherwise
@@ -112,7 +291,7 @@ func (r *SafeQueue[T]) Peek() (T, error) {
 
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("SafeQueue", "Peek", ErrEmpty)
 	}
 
 	result = r.head.read(int(r.headIndex))
@@ -120,36 +299,126 @@ func (r *SafeQueue[T]) Peek() (T, error) {
 	return result, nil
 }
 
-// Return a slice representation of the current state of the queue
-func (r *SafeQueue[T]) ToSlice() []T {
+// Return an element of type T from the beginning of the queue without Dequeuing it, blocking until one is
+// available or ctx is done. Complexity is O(1)
+//
+// Returns an error wrapping ErrClosed if the queue is closed and empty
+func (r *SafeQueue[T]) PeekWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result T
+	if err := r.wait(ctx, "PeekWait"); err != nil {
+		return result, err
+	}
+
+	result = r.head.read(int(r.headIndex))
+
+	return result, nil
+}
+
+// Return the element of type T at position i counted from the front of the queue, where 0 is the element
+// Dequeue would return. Complexity is O(i/chunk size)
+func (r *SafeQueue[T]) At(i int) (T, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	ret := make([]T, 0)
+	var result T
+	if err := checkIndex("SafeQueue", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	node, pos := locateChain(r.head, r.headIndex, uint(i))
+	return node.read(pos), nil
+}
+
+// Replace the element at position i counted from the front of the queue. Complexity is O(i/chunk size)
+func (r *SafeQueue[T]) Set(i int, element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := checkIndex("SafeQueue", "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	node, pos := locateChain(r.head, r.headIndex, uint(i))
+	node.write(element, pos)
+	return nil
+}
+
+// Return the most recently enqueued element of type T without Dequeuing it. Complexity is O(1), except when
+// the last chunk was just filled which takes O(n/chunk size)
+func (r *SafeQueue[T]) PeekBack() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
 	if r.curBuffSize == 0 {
-		return ret
+		return result, newContainerError("SafeQueue", "PeekBack", ErrEmpty)
+	}
+
+	if r.tailIndex > 0 {
+		return r.tail.read(int(r.tailIndex - 1)), nil
 	}
+	node, pos := locateChain(r.head, r.headIndex, r.curBuffSize-1)
+	return node.read(pos), nil
+}
 
-	startingIndex := r.headIndex
-	startingNode := r.head
-	finished := false
+// Return the position, counted from the front, of the first element satisfying f or -1 if there is none.
+// The read lock is held while searching, so f must not call methods of the queue. Complexity is O(n)
+func (r *SafeQueue[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
 
-	for startingNode != nil && !finished {
-		ret = append(ret, startingNode.data[startingIndex])
+// Report whether any element of the queue satisfies f. Complexity is O(n)
+func (r *SafeQueue[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
 
-		if r.tail == startingNode && (startingIndex+1) == r.tailIndex {
-			return ret
-		}
+// Return a slice representation of the current state of the queue
+func (r *SafeQueue[T]) ToSlice() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-		if startingIndex == 999 {
-			startingNode = startingNode.next
-			startingIndex = 0
-		} else {
-			startingIndex++
-		}
+	s := make([]T, 0, r.curBuffSize)
+	walkChain(r.head, r.headIndex, r.curBuffSize, func(_ int, v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// Return an iterator over the elements of the queue from front to back, in Dequeue order.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafeQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkChain(r.head, r.headIndex, r.curBuffSize, func(_ int, v T) bool { return yield(v) })
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafeQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkChainBackward(r.head, r.headIndex, r.curBuffSize, yield)
 	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back.
+// The read lock is held while iterating, so the loop body must not call methods of the queue.
+func (r *SafeQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
 
-	return ret
+		walkChain(r.head, r.headIndex, r.curBuffSize, yield)
+	}
 }
 
 // Return the number of elements in the queue
@@ -158,3 +427,72 @@ func (r *SafeQueue[T]) Count() uint {
 	defer r.mu.RUnlock()
 	return r.curBuffSize
 }
+
+// Remove all elements from the queue. The head chunk is kept and the others are recycled, so a cleared
+// queue can be refilled without allocating. Complexity is O(n)
+func (r *SafeQueue[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pool.putChain(r.head.next)
+	r.head.next = nil
+	clear(r.head.data)
+
+	r.tail = r.head
+	r.curBuffSize = 0
+	r.headIndex = 0
+	r.tailIndex = 0
+}
+
+// Return a copy of the queue which shares no chunks with it. The elements themselves are copied by value.
+// The copy is open even if the queue was closed. The read lock is held while copying. Complexity is O(n)
+func (r *SafeQueue[T]) Clone() *SafeQueue[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	head, tail := cloneChain(r.head)
+	c := &SafeQueue[T]{
+		curBuffSize: r.curBuffSize,
+		headIndex:   r.headIndex,
+		tailIndex:   r.tailIndex,
+		chunkSize:   r.chunkSize,
+		pool:        r.pool.clone(),
+		head:        head,
+		tail:        tail,
+		closer:      newCloser(),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Release the spare chunks kept for reuse, see WithSpareChunks
+func (r *SafeQueue[T]) Shrink() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pool.shrink()
+}
+
+// Close the queue. Further elements make Enqueue panic and are refused by TryEnqueue, while the elements left can
+// still be dequeued. Every goroutine blocked in DequeueWait or PeekWait wakes up and returns ErrClosed
+// once the queue is empty. Closing a closed queue does nothing
+func (r *SafeQueue[T]) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.close() {
+		r.cond.Broadcast()
+	}
+}
+
+// Checks if the queue was closed
+//
+// Return true if closed false otherwise
+func (r *SafeQueue[T]) IsClosed() bool {
+	return r.closer.isClosed()
+}
+
+// Return a channel which is closed when the queue is closed, for use in select statements
+func (r *SafeQueue[T]) Done() <-chan struct{} {
+	return r.closer.done
+}
@@ -1,9 +1,244 @@
 package lists
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
 	"testing"
+	"time"
 )
 
+func TestSafeQueueDequeueWait(t *testing.T) {
+	for name, queue := range map[string]BlockingFifo[int]{
+		"SafeQueue":         NewSafeQueue[int](),
+		"SafeLSQueue":       NewSafeLSQueue[int](10),
+		"SafePriorityQueue": NewSafeOrderedPriorityQueue[int](),
+		"DelayQueue":        NewDelayQueue[int](),
+	} {
+		// an available element is returned straight away
+		queue.Enqueue(1)
+		element, err := queue.DequeueWait(context.Background())
+		if element != 1 || err != nil {
+			t.Errorf("%v DequeueWait() = %v, %v, want %v, %v", name, element, err, 1, nil)
+		}
+
+		// a waiting consumer is woken up by a producer
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			queue.Enqueue(2)
+		}()
+		element, err = queue.PeekWait(context.Background())
+		if element != 2 || err != nil {
+			t.Errorf("%v PeekWait() = %v, %v, want %v, %v", name, element, err, 2, nil)
+		}
+		element, err = queue.DequeueWait(context.Background())
+		if element != 2 || err != nil {
+			t.Errorf("%v DequeueWait() = %v, %v, want %v, %v", name, element, err, 2, nil)
+		}
+
+		// waiting on an empty queue stops with the context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err = queue.DequeueWait(ctx)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%v DequeueWait() = %v, want %v", name, err, context.DeadlineExceeded)
+		}
+
+		ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		_, err = queue.PeekWait(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%v PeekWait() = %v, want %v", name, err, context.Canceled)
+		}
+	}
+}
+
+func TestSafeLSQueueBlockPolicy(t *testing.T) {
+	queue := NewSafeLSQueue(2, WithOverflowPolicy[int](Block))
+	queue.Enqueue(0)
+	queue.Enqueue(1)
+
+	if err := queue.TryEnqueue(2); err == nil {
+		t.Errorf("TryEnqueue() = %v, want %v", err, "full list")
+	}
+
+	// a blocked producer is released by a consumer
+	done := make(chan struct{})
+	go func() {
+		queue.Enqueue(2)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if element, _ := queue.Dequeue(); element != 0 {
+		t.Errorf("Dequeue() = %v, want %v", element, 0)
+	}
+	<-done
+
+	if s := queue.ToSlice(); !slices.Equal(s, []int{1, 2}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{1, 2})
+	}
+}
+
+// Run f and return the error it panicked with, or nil if it returned normally
+func panicError(f func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err, _ = v.(error)
+			if err == nil {
+				err = fmt.Errorf("%v", v)
+			}
+		}
+	}()
+	f()
+	return nil
+}
+
+func TestSafeQueueClose(t *testing.T) {
+	type closableFifo interface {
+		BlockingFifo[int]
+		Closable
+		TryEnqueue(x int) error
+	}
+
+	for name, queue := range map[string]closableFifo{
+		"SafeQueue":   NewSafeQueue[int](),
+		"SafeLSQueue": NewSafeLSQueue[int](10),
+	} {
+		// every waiting consumer is woken up by Close
+		errs := make(chan error, 2)
+		go func() {
+			_, err := queue.DequeueWait(context.Background())
+			errs <- err
+		}()
+		go func() {
+			_, err := queue.PeekWait(context.Background())
+			errs <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		queue.Close()
+		queue.Close()
+		for i := 0; i < 2; i++ {
+			if err := <-errs; !errors.Is(err, ErrClosed) {
+				t.Errorf("%v waiting on a closed queue error = %v, want %v", name, err, ErrClosed)
+			}
+		}
+		select {
+		case <-queue.Done():
+		default:
+			t.Errorf("%v Done() is not closed after Close()", name)
+		}
+		if !queue.IsClosed() {
+			t.Errorf("%v IsClosed() = %v, want %v", name, queue.IsClosed(), true)
+		}
+
+		// producers are refused, loudly if they can't be told through an error
+		if err := panicError(func() { queue.Enqueue(1) }); !errors.Is(err, ErrClosed) {
+			t.Errorf("%v Enqueue() on a closed queue panic = %v, want %v", name, err, ErrClosed)
+		}
+		if err := panicError(func() { queue.EnqueueAll(2, 3) }); !errors.Is(err, ErrClosed) {
+			t.Errorf("%v EnqueueAll() on a closed queue panic = %v, want %v", name, err, ErrClosed)
+		}
+		if err := queue.TryEnqueue(4); !errors.Is(err, ErrClosed) {
+			t.Errorf("%v TryEnqueue() on a closed queue error = %v, want %v", name, err, ErrClosed)
+		}
+		if !queue.IsEmpty() {
+			t.Errorf("%v Count() after Close() = %v, want %v", name, queue.Count(), 0)
+		}
+	}
+
+	// elements enqueued before Close can still be taken
+	queue := NewSafeQueue[int]()
+	queue.EnqueueAll(1, 2)
+	queue.Close()
+	if element, err := queue.DequeueWait(context.Background()); element != 1 || err != nil {
+		t.Errorf("DequeueWait() = %v, %v, want %v, %v", element, err, 1, nil)
+	}
+	if s := slices.Collect(queue.Drain()); !slices.Equal(s, []int{2}) {
+		t.Errorf("Drain() = %v, want %v", s, []int{2})
+	}
+	if _, err := queue.DequeueWait(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("DequeueWait() on a closed queue error = %v, want %v", err, ErrClosed)
+	}
+	if queue.Clone().IsClosed() {
+		t.Errorf("Clone().IsClosed() = %v, want %v", true, false)
+	}
+}
+
+func TestSafeLSQueueCloseReleasesProducers(t *testing.T) {
+	queue := NewSafeLSQueue(1, WithOverflowPolicy[int](Block))
+	queue.Enqueue(0)
+
+	// a producer blocked on a full queue gives up its element and panics
+	errs := make(chan error)
+	go func() {
+		errs <- panicError(func() { queue.Enqueue(1) })
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	if err := <-errs; !errors.Is(err, ErrClosed) {
+		t.Errorf("Enqueue() waiting on a closed queue panic = %v, want %v", err, ErrClosed)
+	}
+
+	if s := queue.ToSlice(); !slices.Equal(s, []int{0}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{0})
+	}
+}
+
+func TestSafeLSQueueEnqueueCtx(t *testing.T) {
+	queue := NewSafeLSQueue(1, WithOverflowPolicy[int](Block))
+	queue.Enqueue(0)
+
+	// a producer waiting for room gives up once the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.EnqueueCtx(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("EnqueueCtx() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	queue.Close()
+	if err := queue.EnqueueCtx(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("EnqueueCtx() = %v, want %v", err, ErrClosed)
+	}
+	if s := queue.ToSlice(); !slices.Equal(s, []int{0}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{0})
+	}
+}
+
+func TestSafeLSQueueEvictionCallbackUsesQueue(t *testing.T) {
+	var queue *SafeLSQueue[int]
+	counts := make([]uint, 0)
+	queue = NewSafeLSQueue(2, WithEvictionCallback(func(int) { counts = append(counts, queue.Count()) }))
+
+	// the callback runs after the lock is released, so it can use the queue
+	queue.EnqueueAll(0, 1, 2, 3)
+	queue.Resize(1)
+	if !slices.Equal(counts, []uint{2, 2, 1}) {
+		t.Errorf("Count() seen by the eviction callback = %v, want %v", counts, []uint{2, 2, 1})
+	}
+}
+
+func TestSafeLSQueueResizeReleasesProducers(t *testing.T) {
+	queue := NewSafeLSQueue(1, WithOverflowPolicy[int](Block))
+	queue.Enqueue(0)
+
+	// a producer blocked on a full queue gives up its element once the queue can hold none
+	done := make(chan struct{})
+	go func() {
+		queue.Enqueue(1)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.Resize(0)
+	<-done
+
+	if queue.Count() != 0 {
+		t.Errorf("Count() = %v, want %v", queue.Count(), 0)
+	}
+}
+
 func BenchmarkRegularSafeQueueEnqueue(b *testing.B) {
 	queue := NewSafeQueue[int]()
 
@@ -12,6 +247,15 @@ func BenchmarkRegularSafeQueueEnqueue(b *testing.B) {
 	}
 }
 
+func BenchmarkRegularSafeQueueEnqueueSlice(b *testing.B) {
+	queue := NewSafeQueue[int]()
+	batch := make([]int, 100)
+
+	for i := 0; i < b.N; i += len(batch) {
+		queue.EnqueueSlice(batch)
+	}
+}
+
 func BenchmarkRegularSafeQueueDequeue(b *testing.B) {
 	queue := NewSafeQueue[int]()
 	b.StopTimer()
@@ -25,6 +269,92 @@ func BenchmarkRegularSafeQueueDequeue(b *testing.B) {
 	}
 }
 
+func BenchmarkRegularSafeQueueDequeueN(b *testing.B) {
+	queue := NewSafeQueue[int]()
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+	}
+
+	b.StartTimer()
+	for !queue.IsEmpty() {
+		queue.DequeueN(100)
+	}
+}
+
+func BenchmarkRegularSafeQueueEnqueueDequeue(b *testing.B) {
+	queue := NewSafeQueue[int]()
+
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+		queue.Dequeue()
+	}
+}
+
+// A producer goroutine feeds the consumer running the benchmark loop
+func BenchmarkRegularSafeQueuePipeline(b *testing.B) {
+	queue := NewSafeQueue[int]()
+	go func() {
+		for i := 0; i < b.N; i++ {
+			queue.Enqueue(i)
+		}
+	}()
+
+	for i := 0; i < b.N; {
+		if _, err := queue.Dequeue(); err == nil {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+func BenchmarkSPSCQueueEnqueueDequeue(b *testing.B) {
+	queue := NewSPSCQueue[int](1024)
+
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+		queue.Dequeue()
+	}
+}
+
+// A producer goroutine feeds the consumer running the benchmark loop
+func BenchmarkSPSCQueuePipeline(b *testing.B) {
+	queue := NewSPSCQueue[int](1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			queue.Enqueue(i)
+		}
+	}()
+
+	for i := 0; i < b.N; {
+		if _, err := queue.Dequeue(); err == nil {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+func BenchmarkSPSCQueuePipelineBatch(b *testing.B) {
+	queue := NewSPSCQueue[int](1024)
+	go func() {
+		batch := make([]int, 64)
+		for i := 0; i < b.N; i += len(batch) {
+			queue.EnqueueSlice(batch[:min(len(batch), b.N-i)])
+		}
+	}()
+
+	dst := make([]int, 64)
+	for i := 0; i < b.N; {
+		if n := queue.DequeueInto(dst); n > 0 {
+			i += n
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
 func BenchmarkLimitedSizeSafeQueueEnqueue(b *testing.B) {
 	queue := NewSafeLSQueue[int](10)
 
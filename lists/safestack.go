@@ -1,7 +1,9 @@
 package lists
 
 import (
-	"errors"
+	"context"
+	"iter"
+	"slices"
 	"sync"
 )
 
@@ -10,31 +12,70 @@ import (
 // Push, which adds an element to the collection, and
 // Pop, which removes the most recently added element.
 // Additionally, a peek operation can, without modifying the stack, return the value of the last element added.
+// Closing the stack with Close lets producers signal that no more elements will come, see Closable.
 type SafeStack[T any] struct {
 	curBuffSize uint
 	index       uint
+	chunkSize   uint
+	pool        chunkPool[T]
 	head        *arrnode[T]
 	mu          sync.RWMutex
+	cond        *sync.Cond // signalled whenever an element is pushed or the stack is closed
+	closer      closer
 }
 
-// Constructs a new Stack with elements of type T
-func NewSafeStack[T any]() Lifo[T] {
-	return &SafeStack[T]{
+// Constructs a new Stack with elements of type T.
+// WithChunkSize changes the number of elements stored per chunk.
+//
+// Returns a pointer to a SafeStack
+func NewSafeStack[T any](opts ...ChunkOption) *SafeStack[T] {
+	config := newChunkConfig(opts)
+	r := &SafeStack[T]{
 		curBuffSize: 0,
-		head:        newArrayNode[T](nil),
-		index:       999,
+		head:        newArrayNode[T](nil, config.chunkSize),
+		index:       config.chunkSize - 1,
+		chunkSize:   config.chunkSize,
+		pool:        newChunkPool[T](config),
+		closer:      newCloser(),
 	}
+	r.cond = sync.NewCond(&r.mu)
+	return r
 }
 
 // Pushes a new element T onto the stack. Complexity is O(1)
+//
+// Panics with an error wrapping ErrClosed if the stack is closed, like a send on a closed channel.
+// Use TryPush where the stack may be closed concurrently
 func (r *SafeStack[T]) Push(element T) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.closer.isClosed() {
+		panic(newContainerError("SafeStack", "Push", ErrClosed))
+	}
+	r.push(element)
+}
+
+// Pushes a new element T onto the stack. Complexity is O(1)
+//
+// Returns an error if the stack is closed
+func (r *SafeStack[T]) TryPush(element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.isClosed() {
+		return newContainerError("SafeStack", "TryPush", ErrClosed)
+	}
+	r.push(element)
+	return nil
+}
+
+// A hidden method which pushes an element. Must be called with the lock held
+func (r *SafeStack[T]) push(element T) {
 	if r.curBuffSize > 0 {
 		if r.index == 0 {
-			r.index = 999
-			newNode := newArrayNode[T](r.head)
+			r.index = r.chunkSize - 1
+			newNode := r.pool.get(r.head)
 			r.head = newNode
 		} else {
 			r.index--
@@ -42,6 +83,47 @@ func (r *SafeStack[T]) Push(element T) {
 	}
 	r.curBuffSize++
 	r.head.write(element, int(r.index))
+	r.cond.Broadcast()
+}
+
+// Pushes elements of type T onto the stack in the given order, so the last one ends up on top.
+// Elements are copied into the head node a whole run at a time and the lock is taken once for the whole batch.
+// Panics like Push if the stack is closed, without pushing any element. Complexity is O(k)
+func (r *SafeStack[T]) PushAll(elements ...T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.isClosed() {
+		panic(newContainerError("SafeStack", "PushAll", ErrClosed))
+	}
+
+	if r.curBuffSize == 0 {
+		r.head.write(elements[0], int(r.index))
+		r.curBuffSize++
+		elements = elements[1:]
+	}
+
+	for len(elements) > 0 {
+		if r.index == 0 {
+			r.head = r.pool.get(r.head)
+			r.index = r.chunkSize
+		}
+
+		// the stack grows downwards, so the run is stored in reverse
+		k := min(int(r.index), len(elements))
+		run := r.head.data[int(r.index)-k : r.index]
+		copy(run, elements[:k])
+		slices.Reverse(run)
+
+		elements = elements[k:]
+		r.index -= uint(k)
+		r.curBuffSize += uint(k)
+	}
+	r.cond.Broadcast()
 }
 
 // Removes the most recently added element T from the stack and returns it. Complexity is O(1)
@@ -49,25 +131,123 @@ func (r *SafeStack[T]) Pop() (T, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.pop()
+}
+
+// Removes the most recently added element T from the stack and returns it, blocking until one is available
+// or ctx is done. Complexity is O(1)
+//
+// Returns an error wrapping ErrClosed if the stack is closed and empty
+func (r *SafeStack[T]) PopWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.wait(ctx, "PopWait"); err != nil {
+		var result T
+		return result, err
+	}
+	return r.pop()
+}
+
+// A hidden method which blocks until the stack holds elements, ctx is done or the stack is closed and
+// empty. Must be called with the lock held
+func (r *SafeStack[T]) wait(ctx context.Context, op string) error {
+	ready := func() bool {
+		return r.curBuffSize > 0 || r.closer.isClosed()
+	}
+	if err := waitFor(ctx, "SafeStack", op, r.cond, ready); err != nil {
+		return err
+	}
+	if r.curBuffSize == 0 {
+		return newContainerError("SafeStack", op, ErrClosed)
+	}
+	return nil
+}
+
+// A hidden method which pops an element. Must be called with the lock held
+func (r *SafeStack[T]) pop() (T, error) {
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("SafeStack", "Pop", ErrEmpty)
 	}
 
 	r.curBuffSize--
-	result = r.head.read(int(r.index))
+	result = r.head.take(int(r.index))
 	r.index++
 
-	if r.index > 999 {
+	if r.index >= r.chunkSize {
 		r.index = 0
 		if r.head.next != nil {
+			node := r.head
 			r.head = r.head.next
+			r.pool.put(node)
 		}
 	}
 
 	return result, nil
 }
 
+// Remove up to len(dst) elements from the top of the stack and copy them into dst in Pop order.
+// The lock is taken once for the whole batch. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *SafeStack[T]) PopInto(dst []T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.popInto(dst)
+}
+
+// Remove up to n elements from the top of the stack and return them in Pop order.
+// The lock is taken once for the whole batch. Complexity is O(n)
+func (r *SafeStack[T]) PopN(n int) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.popInto(s)]
+}
+
+// Return an iterator which pops elements until the stack is empty.
+// Breaking out of the loop leaves the remaining elements on the stack. Every element is popped under
+// its own lock so producers are not held up while the loop body runs; use PopN to take a batch at once.
+func (r *SafeStack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Pop()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// A hidden method which pops up to len(dst) elements into dst. Must be called with the lock held
+func (r *SafeStack[T]) popInto(dst []T) int {
+	n := 0
+	for n < len(dst) && r.curBuffSize > 0 {
+		// copy the run of elements left in the head node in one go
+		end := min(r.chunkSize, r.index+r.curBuffSize)
+		k := uint(copy(dst[n:], r.head.data[r.index:end]))
+		clear(r.head.data[r.index : r.index+k])
+
+		n += int(k)
+		r.curBuffSize -= k
+		r.index += k
+
+		if r.index >= r.chunkSize {
+			r.index = 0
+			if r.head.next != nil {
+				node := r.head
+				r.head = r.head.next
+				r.pool.put(node)
+			}
+		}
+	}
+
+	return n
+}
+
 // Checks to see if the stack is empty.
 //
 // Returns true if stack is empty otherwise false
@@ -80,46 +260,130 @@ func (r *SafeStack[T]) IsEmpty() bool {
 
 // The Peek operation returns, without modifying the stack, the value of the last element T added
 func (r *SafeStack[T]) Peek() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("SafeStack", "Peek", ErrEmpty)
 	}
 
 	return r.head.read(int(r.index)), nil
 }
 
+// The PeekWait operation returns, without modifying the stack, the value of the last element T added,
+// blocking until one is available or ctx is done
+//
+// Returns an error wrapping ErrClosed if the stack is closed and empty
+func (r *SafeStack[T]) PeekWait(ctx context.Context) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.wait(ctx, "PeekWait"); err != nil {
+		var result T
+		return result, err
+	}
+
+	return r.head.read(int(r.index)), nil
+}
+
+// Return the element of type T at position i counted from the top of the stack, where 0 is the element
+// Pop would return. Complexity is O(i/chunk size)
+func (r *SafeStack[T]) At(i int) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result T
+	if err := checkIndex("SafeStack", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
+
+	node, pos := locateChain(r.head, r.index, uint(i))
+	return node.read(pos), nil
+}
+
+// Replace the element at position i counted from the top of the stack. Complexity is O(i/chunk size)
+func (r *SafeStack[T]) Set(i int, element T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := checkIndex("SafeStack", "Set", i, r.curBuffSize); err != nil {
+		return err
+	}
+
+	node, pos := locateChain(r.head, r.index, uint(i))
+	node.write(element, pos)
+	return nil
+}
+
+// Return up to n elements from the top of the stack in Pop order without removing them. Complexity is O(n)
+func (r *SafeStack[T]) PeekN(n int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := make([]T, 0, min(max(n, 0), int(r.curBuffSize)))
+	walkChain(r.head, r.index, uint(cap(s)), func(_ int, v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// Return the position, counted from the top, of the first element satisfying f or -1 if there is none.
+// The read lock is held while searching, so f must not call methods of the stack. Complexity is O(n)
+func (r *SafeStack[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
+
+// Report whether any element of the stack satisfies f. Complexity is O(n)
+func (r *SafeStack[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
 // Return a slice representation of the current state of the stack
 func (r *SafeStack[T]) ToSlice() []T {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	s := make([]T, 0, r.curBuffSize)
+	walkChain(r.head, r.index, r.curBuffSize, func(_ int, v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
 
-	if r.curBuffSize == 0 {
-		return s
+// Return an iterator over the elements of the stack from top to bottom, in Pop order.
+// The read lock is held while iterating, so the loop body must not call methods of the stack.
+func (r *SafeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		walkChain(r.head, r.index, r.curBuffSize, func(_ int, v T) bool { return yield(v) })
 	}
+}
 
-	tmp := r.head
-	index := r.index
-	finished := false
-	cnt := 0
+// Return an iterator over the elements of the stack from bottom to top.
+// The read lock is held while iterating, so the loop body must not call methods of the stack.
+func (r *SafeStack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
 
-	for tmp != nil && !finished {
-		s = append(s, tmp.read(int(index)))
-		cnt++
+		walkChainBackward(r.head, r.index, r.curBuffSize, yield)
+	}
+}
 
-		if index == 999 {
-			index = 0
-			tmp = tmp.next
-		} else {
-			index++
-		}
+// Return an iterator over the positions and elements of the stack from top to bottom.
+// The read lock is held while iterating, so the loop body must not call methods of the stack.
+func (r *SafeStack[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
 
-		if cnt > int(r.curBuffSize) {
-			finished = true
-		}
+		walkChain(r.head, r.index, r.curBuffSize, yield)
 	}
-	return s
 }
 
 // Return the number of elements in the Stack
@@ -129,3 +393,68 @@ func (r *SafeStack[T]) Count() uint {
 
 	return r.curBuffSize
 }
+
+// Remove all elements from the stack. The head chunk is kept and the others are recycled, so a cleared
+// stack can be refilled without allocating. Complexity is O(n)
+func (r *SafeStack[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pool.putChain(r.head.next)
+	r.head.next = nil
+	clear(r.head.data)
+
+	r.curBuffSize = 0
+	r.index = r.chunkSize - 1
+}
+
+// Return a copy of the stack which shares no chunks with it. The elements themselves are copied by value.
+// The copy is open even if the stack was closed. The read lock is held while copying. Complexity is O(n)
+func (r *SafeStack[T]) Clone() *SafeStack[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	head, _ := cloneChain(r.head)
+	c := &SafeStack[T]{
+		curBuffSize: r.curBuffSize,
+		index:       r.index,
+		chunkSize:   r.chunkSize,
+		pool:        r.pool.clone(),
+		head:        head,
+		closer:      newCloser(),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Release the spare chunks kept for reuse, see WithSpareChunks
+func (r *SafeStack[T]) Shrink() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pool.shrink()
+}
+
+// Close the stack. Further elements make Push panic and are refused by TryPush, while the elements left can
+// still be popped. Every goroutine blocked in PopWait or PeekWait wakes up and returns ErrClosed once
+// the stack is empty. Closing a closed stack does nothing
+func (r *SafeStack[T]) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer.close() {
+		r.cond.Broadcast()
+	}
+}
+
+// Checks if the stack was closed
+//
+// Return true if closed false otherwise
+func (r *SafeStack[T]) IsClosed() bool {
+	return r.closer.isClosed()
+}
+
+// Return a channel which is closed when the stack is closed, for use in select statements
+func (r *SafeStack[T]) Done() <-chan struct{} {
+	return r.closer.done
+}
@@ -1,9 +1,81 @@
 package lists
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
+func TestSafeStackPopWait(t *testing.T) {
+	stack := NewSafeStack[int]()
+
+	// a waiting consumer is woken up by a producer
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		stack.Push(1)
+	}()
+	element, err := stack.PeekWait(context.Background())
+	if element != 1 || err != nil {
+		t.Errorf("PeekWait() = %v, %v, want %v, %v", element, err, 1, nil)
+	}
+	element, err = stack.PopWait(context.Background())
+	if element != 1 || err != nil {
+		t.Errorf("PopWait() = %v, %v, want %v, %v", element, err, 1, nil)
+	}
+
+	// waiting on an empty stack stops with the context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = stack.PopWait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PopWait() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSafeStackClose(t *testing.T) {
+	stack := NewSafeStack[int]()
+	stack.PushAll(1, 2)
+
+	// a waiting consumer is woken up by Close once the stack is empty
+	errs := make(chan error)
+	go func() {
+		for {
+			if _, err := stack.PopWait(context.Background()); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	stack.Close()
+	if err := <-errs; !errors.Is(err, ErrClosed) {
+		t.Errorf("PopWait() on a closed stack error = %v, want %v", err, ErrClosed)
+	}
+	if _, err := stack.PeekWait(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("PeekWait() on a closed stack error = %v, want %v", err, ErrClosed)
+	}
+	select {
+	case <-stack.Done():
+	default:
+		t.Errorf("Done() is not closed after Close()")
+	}
+
+	// producers are refused, loudly if they can't be told through an error
+	if err := panicError(func() { stack.Push(3) }); !errors.Is(err, ErrClosed) {
+		t.Errorf("Push() on a closed stack panic = %v, want %v", err, ErrClosed)
+	}
+	if err := panicError(func() { stack.PushAll(4, 5) }); !errors.Is(err, ErrClosed) {
+		t.Errorf("PushAll() on a closed stack panic = %v, want %v", err, ErrClosed)
+	}
+	if err := stack.TryPush(6); !errors.Is(err, ErrClosed) || !stack.IsClosed() {
+		t.Errorf("TryPush() on a closed stack error = %v, want %v", err, ErrClosed)
+	}
+	if !stack.IsEmpty() {
+		t.Errorf("Count() after Close() = %v, want %v", stack.Count(), 0)
+	}
+}
+
 func BenchmarkSafeStackPush(b *testing.B) {
 	stack := NewSafeStack[int]()
 
@@ -24,3 +96,35 @@ func BenchmarkSafeStackPop(b *testing.B) {
 		stack.Pop()
 	}
 }
+
+// Every goroutine pushes and pops in turn
+func benchmarkParallelLifo(b *testing.B, stack Lifo[int]) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			stack.Push(i)
+			stack.Pop()
+			i++
+		}
+	})
+}
+
+func BenchmarkParallelSafeStack(b *testing.B) {
+	benchmarkParallelLifo(b, NewSafeStack[int]())
+}
+
+func BenchmarkParallelLockFreeStack(b *testing.B) {
+	benchmarkParallelLifo(b, NewLockFreeStack[int]())
+}
+
+func BenchmarkParallelLockFreeStackElimination(b *testing.B) {
+	benchmarkParallelLifo(b, NewLockFreeStack[int](WithElimination(8)))
+}
+
+func BenchmarkLockFreeStackPush(b *testing.B) {
+	stack := NewLockFreeStack[int]()
+
+	for i := 0; i < b.N; i++ {
+		stack.Push(i)
+	}
+}
@@ -0,0 +1,376 @@
+package lists
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Struct holding the settings of a ShardedQueue
+type shardedQueueConfig[T any] struct {
+	key func(T) uint64
+}
+
+// ShardedQueueOption configures a ShardedQueue when passed to its constructor
+type ShardedQueueOption[T any] func(*shardedQueueConfig[T])
+
+// Place every element in the shard selected by key instead of spreading elements round-robin. Elements
+// with the same key share a shard and so keep their order
+func WithShardKey[T any](key func(T) uint64) ShardedQueueOption[T] {
+	return func(c *shardedQueueConfig[T]) {
+		c.key = key
+	}
+}
+
+// A shard of a ShardedQueue, padded so the locks of neighbouring shards don't share a cache line
+type queueShard[T any] struct {
+	mu    sync.Mutex
+	queue Fifo[T]
+	_     cacheLinePad
+}
+
+// The ShardedQueue spreads its elements over several independently locked queues, the shards, so that
+// goroutines working on different shards don't contend for a lock. Producers place elements round-robin,
+// or by key with WithShardKey. A consumer starts at the shard after the one the last element was taken
+// from and steals from the following shards while that one is empty, passing over shards whose lock is
+// taken before waiting for them.
+//
+// The queue is FIFO in a relaxed sense only:
+//
+//   - elements in the same shard are dequeued in the order they were enqueued, so with WithShardKey the
+//     elements of one key keep their order
+//   - elements in different shards are not ordered with respect to each other, and an element may be
+//     overtaken by any number of elements enqueued after it
+//   - while no goroutines use the queue concurrently and elements are placed round-robin, the queue is
+//     strictly FIFO, and the iterators follow the order Dequeue would take. DequeueN and DequeueInto drain
+//     one shard at a time instead, so a batch is only FIFO within each shard
+//   - every element is dequeued exactly once, and Dequeue only fails if it found every shard empty,
+//     so an element enqueued at the same time may be missed
+//
+// ShardedQueue is thread safe. However only the queue structure itself is safe. It is up to the
+// developer to ensure thread safety of the internals of the data.
+//
+// ShardedQueue is a list that implements the Fifo interface
+type ShardedQueue[T any] struct {
+	shards      []queueShard[T]
+	key         func(T) uint64
+	_           cacheLinePad
+	enqueueNext atomic.Uint64 // the next shard of round-robin placement
+	_           cacheLinePad
+	dequeueNext atomic.Uint64 // the shard a consumer looks at first
+	_           cacheLinePad
+	count       atomic.Int64
+}
+
+// The constructor for a new ShardedQueue instance with elements of type T spread over n shards.
+// An n of 0 selects one shard per processor as given by runtime.GOMAXPROCS.
+// WithShardKey places elements by key instead of round-robin.
+//
+// Returns a pointer to a ShardedQueue
+func NewShardedQueue[T any](n uint, opts ...ShardedQueueOption[T]) *ShardedQueue[T] {
+	config := shardedQueueConfig[T]{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if n == 0 {
+		n = uint(runtime.GOMAXPROCS(0))
+	}
+
+	r := &ShardedQueue[T]{
+		shards: make([]queueShard[T], n),
+		key:    config.key,
+	}
+	for i := range r.shards {
+		r.shards[i].queue = NewQueue[T]()
+	}
+	return r
+}
+
+// A hidden method which returns the shard the next round-robin element goes to, reserving k positions
+func (r *ShardedQueue[T]) reserve(k int) uint64 {
+	return (r.enqueueNext.Add(uint64(k)) - uint64(k)) % uint64(len(r.shards))
+}
+
+// A hidden method which returns the shard of element
+func (r *ShardedQueue[T]) shardOf(element T) uint64 {
+	if r.key != nil {
+		return r.key(element) % uint64(len(r.shards))
+	}
+	return r.reserve(1)
+}
+
+// A hidden method which locks and returns the first shard holding elements, together with its index,
+// starting with the one after the last shard taken from. Busy shards are passed over first and only waited
+// for if no other shard holds elements. The caller has to unlock the shard.
+//
+// Returns nil if every shard was empty
+func (r *ShardedQueue[T]) lockNonEmpty() (*queueShard[T], uint64) {
+	n := uint64(len(r.shards))
+	start := r.dequeueNext.Load()
+
+	// bit k is set if shard start+k was busy. Busy shards beyond the first 64 are only remembered together
+	var busy uint64
+	busyBeyond := false
+	for k := uint64(0); k < n; k++ {
+		i := (start + k) % n
+		shard := &r.shards[i]
+		if !shard.mu.TryLock() {
+			if k < 64 {
+				busy |= 1 << k
+			} else {
+				busyBeyond = true
+			}
+			continue
+		}
+		if !shard.queue.IsEmpty() {
+			return shard, i
+		}
+		shard.mu.Unlock()
+	}
+
+	for k := uint64(0); k < n; k++ {
+		if (k < 64 && busy&(1<<k) == 0) || (k >= 64 && !busyBeyond) {
+			continue
+		}
+		i := (start + k) % n
+		shard := &r.shards[i]
+		shard.mu.Lock()
+		if !shard.queue.IsEmpty() {
+			return shard, i
+		}
+		shard.mu.Unlock()
+	}
+	return nil, 0
+}
+
+// Return the number of elements in the queue. -1 means unlimited
+func (r *ShardedQueue[T]) Capacity() int {
+	return -1
+}
+
+// Return the number of shards
+func (r *ShardedQueue[T]) Shards() int {
+	return len(r.shards)
+}
+
+// Return the number of elements in the queue. While other goroutines are active the count may be
+// outdated as soon as it is returned
+func (r *ShardedQueue[T]) Count() uint {
+	return uint(max(r.count.Load(), 0))
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *ShardedQueue[T]) IsEmpty() bool {
+	return r.Count() == 0
+}
+
+// Checks if the queue is full. Can never be full but just for interface implementation
+func (r *ShardedQueue[T]) IsFull() bool {
+	return false
+}
+
+// Add an element of type T to the end of its shard. Complexity is O(1)
+func (r *ShardedQueue[T]) Enqueue(element T) {
+	shard := &r.shards[r.shardOf(element)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	r.count.Add(1)
+	shard.queue.Enqueue(element)
+}
+
+// Add elements of type T in the given order, placed as if they were enqueued one by one.
+// The lock of every shard receiving elements is taken once for the whole batch. Complexity is O(k+s)
+// for s shards
+func (r *ShardedQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice in order, placed as if they were enqueued one by one.
+// The lock of every shard receiving elements is taken once for the whole batch. Complexity is O(k+s)
+// for s shards
+func (r *ShardedQueue[T]) EnqueueSlice(elements []T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	n := uint64(len(r.shards))
+	buckets := make([][]T, n)
+	if r.key != nil {
+		for _, element := range elements {
+			i := r.key(element) % n
+			buckets[i] = append(buckets[i], element)
+		}
+	} else {
+		start := r.reserve(len(elements))
+		for j, element := range elements {
+			i := (start + uint64(j)) % n
+			buckets[i] = append(buckets[i], element)
+		}
+	}
+
+	r.count.Add(int64(len(elements)))
+	for i, bucket := range buckets {
+		if len(bucket) == 0 {
+			continue
+		}
+		shard := &r.shards[i]
+		shard.mu.Lock()
+		shard.queue.EnqueueSlice(bucket)
+		shard.mu.Unlock()
+	}
+}
+
+// Remove and return the front element of the first shard holding elements, starting with the one after the
+// last shard taken from. Complexity is O(1) plus O(s) for s shards when stealing
+//
+// Returns an error if every shard was empty
+func (r *ShardedQueue[T]) Dequeue() (T, error) {
+	shard, i := r.lockNonEmpty()
+	if shard == nil {
+		var result T
+		return result, newContainerError("ShardedQueue", "Dequeue", ErrEmpty)
+	}
+	defer shard.mu.Unlock()
+
+	r.dequeueNext.Store(i + 1)
+	element, err := shard.queue.Dequeue()
+	if err == nil {
+		r.count.Add(-1)
+	}
+	return element, err
+}
+
+// Remove up to len(dst) elements and copy them into dst. Every shard holding elements is locked once and
+// drained as far as dst allows before moving on to the next, so the elements of a shard keep their order
+// but, unlike with Dequeue, the shards are not interleaved. Complexity is O(k) plus O(s) for s shards
+//
+// Returns the number of elements copied
+func (r *ShardedQueue[T]) DequeueInto(dst []T) int {
+	k := 0
+	for k < len(dst) {
+		shard, i := r.lockNonEmpty()
+		if shard == nil {
+			break
+		}
+		n := shard.queue.DequeueInto(dst[k:])
+		r.dequeueNext.Store(i + 1)
+		r.count.Add(-int64(n))
+		shard.mu.Unlock()
+		k += n
+	}
+	return k
+}
+
+// Remove up to n elements and return them, taken shard by shard as DequeueInto does. Complexity is O(n)
+func (r *ShardedQueue[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.Count())))
+	k := r.DequeueInto(s)
+	for ; k == len(s) && k < n; k++ {
+		element, err := r.Dequeue()
+		if err != nil {
+			break
+		}
+		s = append(s, element)
+	}
+	return s[:k]
+}
+
+// Return an iterator which dequeues elements until every shard is empty.
+// Breaking out of the loop leaves the remaining elements in the queue
+func (r *ShardedQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return the element the next Dequeue would return without Dequeuing it, if no other goroutine uses the
+// queue meanwhile. Complexity is O(1) plus O(s) for s shards when stealing
+func (r *ShardedQueue[T]) Peek() (T, error) {
+	shard, _ := r.lockNonEmpty()
+	if shard == nil {
+		var result T
+		return result, newContainerError("ShardedQueue", "Peek", ErrEmpty)
+	}
+	defer shard.mu.Unlock()
+
+	return shard.queue.Peek()
+}
+
+// A hidden method which copies the elements of every shard, locking one shard at a time, and arranges them
+// in the order Dequeue would take them
+func (r *ShardedQueue[T]) snapshot() []T {
+	n := len(r.shards)
+	copies := make([][]T, n)
+	total := 0
+	for i := range r.shards {
+		shard := &r.shards[i]
+		shard.mu.Lock()
+		copies[i] = shard.queue.ToSlice()
+		shard.mu.Unlock()
+		total += len(copies[i])
+	}
+
+	s := make([]T, 0, total)
+	next := int(r.dequeueNext.Load() % uint64(n))
+	for len(s) < total {
+		for len(copies[next]) == 0 {
+			next = (next + 1) % n
+		}
+		s = append(s, copies[next][0])
+		copies[next] = copies[next][1:]
+		next = (next + 1) % n
+	}
+	return s
+}
+
+// Return a slice representation of the queue in the order Dequeue would take the elements. The shards are
+// copied one at a time, so this is not a snapshot of the whole queue at one point in time. Complexity is O(n)
+func (r *ShardedQueue[T]) ToSlice() []T {
+	return r.snapshot()
+}
+
+// Return an iterator over the elements of the queue in the order Dequeue would take them.
+// The iterator works on a copy made as ToSlice does, so the loop body may use the queue
+func (r *ShardedQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range r.snapshot() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the queue in the reverse of the order Dequeue would take them.
+// The iterator works on a copy made as ToSlice does, so the loop body may use the queue
+func (r *ShardedQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range slices.Backward(r.snapshot()) {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the queue in the order Dequeue would take them.
+// The iterator works on a copy made as ToSlice does, so the loop body may use the queue
+func (r *ShardedQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, element := range r.snapshot() {
+			if !yield(i, element) {
+				return
+			}
+		}
+	}
+}
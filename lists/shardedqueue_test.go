@@ -0,0 +1,120 @@
+package lists
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShardedQueue(t *testing.T) {
+	queue := NewShardedQueue[int](3)
+
+	if queue.Shards() != 3 {
+		t.Errorf("Shards() = %v, want %v", queue.Shards(), 3)
+	}
+	if NewShardedQueue[int](0).Shards() < 1 {
+		t.Errorf("Shards() of the default queue = %v, want at least %v", NewShardedQueue[int](0).Shards(), 1)
+	}
+
+	// interleaving single enqueues and batches keeps the round-robin placement in step with Dequeue
+	queue.Enqueue(0)
+	queue.EnqueueAll(1, 2, 3, 4)
+	queue.Enqueue(5)
+	if element, _ := queue.Dequeue(); element != 0 {
+		t.Errorf("Dequeue() = %v, want %v", element, 0)
+	}
+	queue.EnqueueSlice([]int{6, 7})
+	if got, want := queue.ToSlice(), []int{1, 2, 3, 4, 5, 6, 7}; !slices.Equal(got, want) {
+		t.Errorf("ToSlice() = %v, want %v", got, want)
+	}
+	if element, _ := queue.Peek(); element != 1 {
+		t.Errorf("Peek() = %v, want %v", element, 1)
+	}
+	if got, want := slices.Collect(queue.Drain()), []int{1, 2, 3, 4, 5, 6, 7}; !slices.Equal(got, want) {
+		t.Errorf("Drain() = %v, want %v", got, want)
+	}
+	if _, err := queue.Dequeue(); err == nil {
+		t.Errorf("Dequeue() on an empty queue error = %v, want %v", err, ErrEmpty)
+	}
+
+	// the iterator copies the queue when the loop starts, not when it is created
+	all := queue.All()
+	queue.EnqueueAll(8, 9)
+	if got, want := slices.Collect(all), []int{8, 9}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
+
+func TestShardedQueueBatchDequeue(t *testing.T) {
+	queue := NewShardedQueue[int](4)
+	elements := make([]int, 2500)
+	for i := range elements {
+		elements[i] = i
+	}
+	queue.EnqueueSlice(elements)
+
+	// every shard is drained as far as the batch allows before the next one, keeping its own order
+	got := queue.DequeueN(1200)
+	dst := make([]int, 2000)
+	n := queue.DequeueInto(dst)
+	got = append(got, dst[:n]...)
+	if len(got) != 2500 || queue.Count() != 0 || !queue.IsEmpty() {
+		t.Errorf("DequeueN(), DequeueInto() = %v elements leaving %v, want %v leaving %v", len(got), queue.Count(), 2500, 0)
+	}
+	if s := queue.DequeueN(10); len(s) != 0 {
+		t.Errorf("DequeueN() = %v, want %v", s, []int{})
+	}
+
+	last := []int{-1, -1, -1, -1}
+	for _, element := range got {
+		if shard := element % 4; element <= last[shard] {
+			t.Fatalf("element %v of shard %v came after %v", element, shard, last[shard])
+		} else {
+			last[shard] = element
+		}
+	}
+	slices.Sort(got)
+	for i, element := range got {
+		if element != i {
+			t.Fatalf("sorted batches[%v] = %v, want %v", i, element, i)
+		}
+	}
+}
+
+func TestShardedQueueKey(t *testing.T) {
+	queue := NewShardedQueue[int](4, WithShardKey(func(x int) uint64 { return uint64(x % 2) }))
+
+	queue.EnqueueAll(0, 2, 4, 1, 3)
+	queue.Enqueue(6)
+
+	// only two shards are used, each keeps the order of its key
+	var even, odd []int
+	for element := range queue.Drain() {
+		if element%2 == 0 {
+			even = append(even, element)
+		} else {
+			odd = append(odd, element)
+		}
+	}
+	if want := []int{0, 2, 4, 6}; !slices.Equal(even, want) {
+		t.Errorf("Drain() even elements = %v, want %v", even, want)
+	}
+	if want := []int{1, 3}; !slices.Equal(odd, want) {
+		t.Errorf("Drain() odd elements = %v, want %v", odd, want)
+	}
+}
+
+func TestShardedQueueConcurrent(t *testing.T) {
+	// round-robin placement only promises every element comes out once
+	stressFifo(t, "ShardedQueue", NewShardedQueue[int](4), false)
+	// placing every producer in one shard keeps its elements in order
+	byProducer := WithShardKey(func(x int) uint64 { return uint64(x / stressElements) })
+	stressFifo(t, "ShardedQueue by key", NewShardedQueue[int](3, byProducer), true)
+}
+
+func BenchmarkParallelShardedQueue(b *testing.B) {
+	benchmarkParallelFifo(b, NewShardedQueue[int](0))
+}
+
+func BenchmarkParallelShardedQueueByKey(b *testing.B) {
+	benchmarkParallelFifo(b, NewShardedQueue[int](0, WithShardKey(func(x int) uint64 { return uint64(x) })))
+}
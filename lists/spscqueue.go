@@ -0,0 +1,259 @@
+package lists
+
+import (
+	"iter"
+	"runtime"
+	"sync/atomic"
+)
+
+// The SPSCQueue is a lock-free queue for exactly one producer and one consumer goroutine, such as the
+// stages of a pipeline. It is a ring whose size is a power of two, and the producer and the consumer each
+// own one of its two indices, so neither ever waits for a lock held by the other.
+//
+// Only one goroutine at a time may act as the producer, calling Enqueue, EnqueueAll, EnqueueSlice and
+// TryEnqueue, and only one as the consumer, calling Dequeue, Peek, the batch methods and the iterators.
+// Capacity, Count, IsEmpty and IsFull may be called from anywhere. It is up to the developer to ensure
+// thread safety of the internals of the data.
+//
+// SPSCQueue is a list that implements the Fifo interface. Enqueue waits for the consumer while the
+// queue is full by spinning and yielding the processor, which burns CPU for as long as the queue stays full.
+// TryEnqueue fails instead, and suits producers which can back off or wait on something else.
+type SPSCQueue[T any] struct {
+	data []T
+	mask uint64
+
+	_          cacheLinePad
+	head       atomic.Uint64 // position of the next element to dequeue, only written by the consumer
+	cachedTail uint64        // the consumer's last view of tail, so it only reads tail when it looks empty
+	_          cacheLinePad
+	tail       atomic.Uint64 // position of the next free slot, only written by the producer
+	cachedHead uint64        // the producer's last view of head, so it only reads head when it looks full
+	_          cacheLinePad
+}
+
+// The constructor for a new SPSCQueue instance with elements of type T which holds at least size elements.
+// The size is rounded up to a power of two, and a size of 0 is treated as 1. Panics with an error wrapping
+// ErrOutOfRange if size is above 1<<63, which can't be rounded up.
+//
+// Returns a pointer to a SPSCQueue
+func NewSPSCQueue[T any](size uint) *SPSCQueue[T] {
+	n := ringSize("SPSCQueue", "NewSPSCQueue", size, 1)
+	return &SPSCQueue[T]{
+		data: make([]T, n),
+		mask: n - 1,
+	}
+}
+
+// A hidden method which returns the number of free slots as seen by the producer, reading head again only
+// when the cached view shows none
+func (r *SPSCQueue[T]) room(tail uint64) uint64 {
+	capacity := uint64(len(r.data))
+	if tail-r.cachedHead == capacity {
+		r.cachedHead = r.head.Load()
+	}
+	return capacity - (tail - r.cachedHead)
+}
+
+// A hidden method which returns the number of elements as seen by the consumer, reading tail again only
+// when the cached view shows none
+func (r *SPSCQueue[T]) available(head uint64) uint64 {
+	if r.cachedTail == head {
+		r.cachedTail = r.tail.Load()
+	}
+	return r.cachedTail - head
+}
+
+// Return the number of elements the queue can hold
+func (r *SPSCQueue[T]) Capacity() int {
+	return len(r.data)
+}
+
+// Return the number of elements in the queue. While the producer or the consumer is active the count
+// may be outdated as soon as it is returned
+func (r *SPSCQueue[T]) Count() uint {
+	head := r.head.Load()
+	tail := r.tail.Load()
+	return uint(min(tail-head, uint64(len(r.data))))
+}
+
+// Checks if the queue is empty
+//
+// Return true if empty false otherwise
+func (r *SPSCQueue[T]) IsEmpty() bool {
+	return r.Count() == 0
+}
+
+// Checks if the queue is full
+//
+// Return true if the queue holds Capacity elements. Otherwise returns false.
+func (r *SPSCQueue[T]) IsFull() bool {
+	return r.Count() == uint(len(r.data))
+}
+
+// Add an element of type T to the end of the queue, spinning until the consumer makes room while the queue
+// is full. Producer only. Complexity is O(1)
+func (r *SPSCQueue[T]) Enqueue(element T) {
+	for r.TryEnqueue(element) != nil {
+		runtime.Gosched()
+	}
+}
+
+// Add an element of type T to the end of the queue. Producer only. Complexity is O(1)
+//
+// Returns an error if the queue is full
+func (r *SPSCQueue[T]) TryEnqueue(element T) error {
+	tail := r.tail.Load()
+	if r.room(tail) == 0 {
+		return newContainerError("SPSCQueue", "TryEnqueue", ErrFull)
+	}
+
+	r.data[tail&r.mask] = element
+	r.tail.Store(tail + 1) // publishes the element to the consumer
+	return nil
+}
+
+// Add elements of type T to the end of the queue in the given order, waiting for the consumer while the
+// queue is full. Producer only. Complexity is O(k)
+func (r *SPSCQueue[T]) EnqueueAll(elements ...T) {
+	r.EnqueueSlice(elements)
+}
+
+// Add the elements of a slice to the end of the queue in order, spinning until the consumer makes room while
+// the queue is full. Elements are published to the consumer as many at a time as there is room for. Producer only.
+// Complexity is O(k)
+func (r *SPSCQueue[T]) EnqueueSlice(elements []T) {
+	for len(elements) > 0 {
+		tail := r.tail.Load()
+		n := min(r.room(tail), uint64(len(elements)))
+		if n == 0 {
+			runtime.Gosched()
+			continue
+		}
+
+		for i := uint64(0); i < n; i++ {
+			r.data[(tail+i)&r.mask] = elements[i]
+		}
+		r.tail.Store(tail + n)
+		elements = elements[n:]
+	}
+}
+
+// Remove and return an element of type T from the beginning of the queue. Consumer only. Complexity is O(1)
+func (r *SPSCQueue[T]) Dequeue() (T, error) {
+	var result T
+	head := r.head.Load()
+	if r.available(head) == 0 {
+		return result, newContainerError("SPSCQueue", "Dequeue", ErrEmpty)
+	}
+
+	i := head & r.mask
+	result = r.data[i]
+	var zero T
+	r.data[i] = zero       // don't keep the value reachable
+	r.head.Store(head + 1) // hands the slot back to the producer
+	return result, nil
+}
+
+// Remove up to len(dst) elements from the beginning of the queue and copy them into dst in Dequeue order.
+// The slots are handed back to the producer at once. Consumer only. Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *SPSCQueue[T]) DequeueInto(dst []T) int {
+	head := r.head.Load()
+	n := min(r.available(head), uint64(len(dst)))
+	var zero T
+
+	for i := uint64(0); i < n; i++ {
+		j := (head + i) & r.mask
+		dst[i] = r.data[j]
+		r.data[j] = zero
+	}
+
+	r.head.Store(head + n)
+	return int(n)
+}
+
+// Remove up to n elements from the beginning of the queue and return them in Dequeue order.
+// Consumer only. Complexity is O(n)
+func (r *SPSCQueue[T]) DequeueN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.available(r.head.Load()))))
+	return s[:r.DequeueInto(s)]
+}
+
+// Return an iterator which dequeues elements until the queue is empty.
+// Breaking out of the loop leaves the remaining elements in the queue. Consumer only
+func (r *SPSCQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, err := r.Dequeue()
+			if err != nil || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Return an element of type T from the beginning of the queue without Dequeuing it. Consumer only.
+// Complexity is O(1)
+func (r *SPSCQueue[T]) Peek() (T, error) {
+	head := r.head.Load()
+	if r.available(head) == 0 {
+		var result T
+		return result, newContainerError("SPSCQueue", "Peek", ErrEmpty)
+	}
+
+	return r.data[head&r.mask], nil
+}
+
+// A hidden method which returns the bounds of the elements published so far
+func (r *SPSCQueue[T]) bounds() (uint64, uint64) {
+	r.cachedTail = r.tail.Load()
+	return r.head.Load(), r.cachedTail
+}
+
+// Return a slice representation of the queue in Dequeue order. Consumer only. Complexity is O(n)
+func (r *SPSCQueue[T]) ToSlice() []T {
+	head, tail := r.bounds()
+	s := make([]T, 0, tail-head)
+	for i := head; i != tail; i++ {
+		s = append(s, r.data[i&r.mask])
+	}
+	return s
+}
+
+// Return an iterator over the elements of the queue from front to back, in Dequeue order.
+// Elements enqueued while iterating may be missed. Consumer only
+func (r *SPSCQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		head, tail := r.bounds()
+		for i := head; i != tail; i++ {
+			if !yield(r.data[i&r.mask]) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the elements of the queue from back to front. Consumer only
+func (r *SPSCQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		head, tail := r.bounds()
+		for i := tail; i != head; i-- {
+			if !yield(r.data[(i-1)&r.mask]) {
+				return
+			}
+		}
+	}
+}
+
+// Return an iterator over the positions and elements of the queue from front to back. Consumer only
+func (r *SPSCQueue[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		head, tail := r.bounds()
+		for i := head; i != tail; i++ {
+			if !yield(int(i-head), r.data[i&r.mask]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package lists
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestSPSCQueue(t *testing.T) {
+	queue := NewSPSCQueue[int](5)
+
+	// the size is rounded up to a power of two
+	if queue.Capacity() != 8 {
+		t.Errorf("Capacity() = %v, want %v", queue.Capacity(), 8)
+	}
+	if _, err := queue.Dequeue(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Dequeue() on an empty queue error = %v, want %v", err, ErrEmpty)
+	}
+
+	// go round the ring a few times
+	next := 0
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 8; i++ {
+			if err := queue.TryEnqueue(round*8 + i); err != nil {
+				t.Fatalf("TryEnqueue() error = %v, want %v", err, nil)
+			}
+		}
+		if err := queue.TryEnqueue(-1); !errors.Is(err, ErrFull) || !queue.IsFull() {
+			t.Errorf("TryEnqueue() on a full queue error = %v, want %v", err, ErrFull)
+		}
+		for i := 0; i < 5; i++ {
+			if element, _ := queue.Dequeue(); element != next {
+				t.Fatalf("Dequeue() = %v, want %v", element, next)
+			}
+			next++
+		}
+		if s := queue.DequeueN(3); !slices.Equal(s, []int{next, next + 1, next + 2}) {
+			t.Fatalf("DequeueN() = %v, want %v", s, []int{next, next + 1, next + 2})
+		}
+		next += 3
+	}
+
+	// the iterators see elements enqueued after the last Dequeue
+	queue.EnqueueAll(100, 101)
+	queue.Dequeue()
+	queue.Enqueue(102)
+	if s := queue.ToSlice(); !slices.Equal(s, []int{101, 102}) {
+		t.Errorf("ToSlice() = %v, want %v", s, []int{101, 102})
+	}
+
+	if NewSPSCQueue[int](0).Capacity() != 1 {
+		t.Errorf("Capacity() of a zero size queue = %v, want %v", NewSPSCQueue[int](0).Capacity(), 1)
+	}
+
+	// a size no power of two in a uint64 reaches is rejected instead of looping forever
+	if uint64(^uint(0)) > maxRingSize {
+		if err := panicError(func() { NewSPSCQueue[int](^uint(0)) }); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("NewSPSCQueue() with a size above %v panic = %v, want %v", maxRingSize, err, ErrOutOfRange)
+		}
+	}
+}
+
+func TestSPSCQueueConcurrent(t *testing.T) {
+	const n = 100000
+	queue := NewSPSCQueue[int](64)
+
+	// the producer mixes single and batch enqueues, which wait while the queue is full
+	go func() {
+		batch := make([]int, 0, 100)
+		for i := 0; i < n; {
+			if i%3 == 0 {
+				queue.Enqueue(i)
+				i++
+				continue
+			}
+			batch = batch[:0]
+			for j := 0; j < 100 && i < n; j++ {
+				batch = append(batch, i)
+				i++
+			}
+			queue.EnqueueSlice(batch)
+		}
+	}()
+
+	dst := make([]int, 10)
+	for next := 0; next < n; {
+		if next%2 == 0 {
+			if element, err := queue.Dequeue(); err == nil {
+				if element != next {
+					t.Fatalf("Dequeue() = %v, want %v", element, next)
+				}
+				next++
+			} else {
+				runtime.Gosched()
+			}
+			continue
+		}
+		for _, element := range dst[:queue.DequeueInto(dst)] {
+			if element != next {
+				t.Fatalf("DequeueInto() = %v, want %v", element, next)
+			}
+			next++
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package lists
 
-import "errors"
+import (
+	"iter"
+	"slices"
+)
 
 // A Stack is an abstract data type that serves as a collection of elements with two main operations:
 //
@@ -10,15 +13,23 @@ import "errors"
 type Stack[T any] struct {
 	curBuffSize uint
 	index       uint
+	chunkSize   uint
+	pool        chunkPool[T]
 	head        *arrnode[T]
 }
 
-// Constructs a new Stack with elements of type T
-func NewStack[T any]() Lifo[T] {
+// Constructs a new Stack with elements of type T.
+// WithChunkSize changes the number of elements stored per chunk.
+//
+// Returns a pointer to a Stack
+func NewStack[T any](opts ...ChunkOption) *Stack[T] {
+	config := newChunkConfig(opts)
 	return &Stack[T]{
 		curBuffSize: 0,
-		head:        newArrayNode[T](nil),
-		index:       999,
+		head:        newArrayNode[T](nil, config.chunkSize),
+		index:       config.chunkSize - 1,
+		chunkSize:   config.chunkSize,
+		pool:        newChunkPool[T](config),
 	}
 }
 
@@ -26,8 +37,8 @@ func NewStack[T any]() Lifo[T] {
 func (r *Stack[T]) Push(element T) {
 	if r.curBuffSize > 0 {
 		if r.index == 0 {
-			r.index = 999
-			newNode := newArrayNode[T](r.head)
+			r.index = r.chunkSize - 1
+			newNode := r.pool.get(r.head)
 			r.head = newNode
 		} else {
 			r.index--
@@ -37,27 +48,103 @@ func (r *Stack[T]) Push(element T) {
 	r.head.write(element, int(r.index))
 }
 
+// Pushes elements of type T onto the stack in the given order, so the last one ends up on top.
+// Elements are copied into the head node a whole run at a time. Complexity is O(k)
+func (r *Stack[T]) PushAll(elements ...T) {
+	if len(elements) > 0 && r.curBuffSize == 0 {
+		r.Push(elements[0])
+		elements = elements[1:]
+	}
+
+	for len(elements) > 0 {
+		if r.index == 0 {
+			r.head = r.pool.get(r.head)
+			r.index = r.chunkSize
+		}
+
+		// the stack grows downwards, so the run is stored in reverse
+		k := min(int(r.index), len(elements))
+		run := r.head.data[int(r.index)-k : r.index]
+		copy(run, elements[:k])
+		slices.Reverse(run)
+
+		elements = elements[k:]
+		r.index -= uint(k)
+		r.curBuffSize += uint(k)
+	}
+}
+
 // Removes the most recently added element T from the stack and returns it. Complexity is O(1)
 func (r *Stack[T]) Pop() (T, error) {
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("Stack", "Pop", ErrEmpty)
 	}
 
 	r.curBuffSize--
-	result = r.head.read(int(r.index))
+	result = r.head.take(int(r.index))
 	r.index++
 
-	if r.index > 999 {
+	if r.index >= r.chunkSize {
 		r.index = 0
 		if r.head.next != nil {
+			node := r.head
 			r.head = r.head.next
+			r.pool.put(node)
 		}
 	}
 
 	return result, nil
 }
 
+// Remove up to len(dst) elements from the top of the stack and copy them into dst in Pop order.
+// Complexity is O(k)
+//
+// Returns the number of elements copied
+func (r *Stack[T]) PopInto(dst []T) int {
+	n := 0
+	for n < len(dst) && r.curBuffSize > 0 {
+		// copy the run of elements left in the head node in one go
+		end := min(r.chunkSize, r.index+r.curBuffSize)
+		k := uint(copy(dst[n:], r.head.data[r.index:end]))
+		clear(r.head.data[r.index : r.index+k])
+
+		n += int(k)
+		r.curBuffSize -= k
+		r.index += k
+
+		if r.index >= r.chunkSize {
+			r.index = 0
+			if r.head.next != nil {
+				node := r.head
+				r.head = r.head.next
+				r.pool.put(node)
+			}
+		}
+	}
+
+	return n
+}
+
+// Remove up to n elements from the top of the stack and return them in Pop order. Complexity is O(n)
+func (r *Stack[T]) PopN(n int) []T {
+	s := make([]T, min(max(n, 0), int(r.curBuffSize)))
+	return s[:r.PopInto(s)]
+}
+
+// Return an iterator which pops elements until the stack is empty.
+// Breaking out of the loop leaves the remaining elements on the stack
+func (r *Stack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r.curBuffSize > 0 {
+			element, _ := r.Pop()
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
 // Checks to see if the stack is empty.
 //
 // Returns true if stack is empty otherwise false
@@ -69,44 +156,116 @@ func (r *Stack[T]) IsEmpty() bool {
 func (r *Stack[T]) Peek() (T, error) {
 	var result T
 	if r.curBuffSize == 0 {
-		return result, errors.New("empty list")
+		return result, newContainerError("Stack", "Peek", ErrEmpty)
 	}
 
 	return r.head.read(int(r.index)), nil
 }
 
-// Return a slice representation of the current state of the stack
-func (r *Stack[T]) ToSlice() []T {
-	s := make([]T, 0, r.curBuffSize)
+// Return the element of type T at position i counted from the top of the stack, where 0 is the element
+// Pop would return. Complexity is O(i/chunk size)
+func (r *Stack[T]) At(i int) (T, error) {
+	var result T
+	if err := checkIndex("Stack", "At", i, r.curBuffSize); err != nil {
+		return result, err
+	}
 
-	if r.curBuffSize == 0 {
-		return s
+	node, pos := locateChain(r.head, r.index, uint(i))
+	return node.read(pos), nil
+}
+
+// Replace the element at position i counted from the top of the stack. Complexity is O(i/chunk size)
+func (r *Stack[T]) Set(i int, element T) error {
+	if err := checkIndex("Stack", "Set", i, r.curBuffSize); err != nil {
+		return err
 	}
 
-	tmp := r.head
-	index := r.index
-	finished := false
-	cnt := 0
+	node, pos := locateChain(r.head, r.index, uint(i))
+	node.write(element, pos)
+	return nil
+}
 
-	for tmp != nil && !finished {
-		s = append(s, tmp.read(int(index)))
-		cnt++
+// Return up to n elements from the top of the stack in Pop order without removing them. Complexity is O(n)
+func (r *Stack[T]) PeekN(n int) []T {
+	s := make([]T, 0, min(max(n, 0), int(r.curBuffSize)))
+	walkChain(r.head, r.index, uint(cap(s)), func(_ int, v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
 
-		if index == 999 {
-			index = 0
-			tmp = tmp.next
-		} else {
-			index++
-		}
+// Return the position, counted from the top, of the first element satisfying f or -1 if there is none.
+// Complexity is O(n)
+func (r *Stack[T]) IndexFunc(f func(T) bool) int {
+	return indexFunc(r.Indexed(), f)
+}
 
-		if cnt > int(r.curBuffSize) {
-			finished = true
-		}
+// Report whether any element of the stack satisfies f. Complexity is O(n)
+func (r *Stack[T]) ContainsFunc(f func(T) bool) bool {
+	return r.IndexFunc(f) >= 0
+}
+
+// Return a slice representation of the current state of the stack
+func (r *Stack[T]) ToSlice() []T {
+	s := make([]T, 0, r.curBuffSize)
+	for v := range r.All() {
+		s = append(s, v)
 	}
 	return s
 }
 
+// Return an iterator over the elements of the stack from top to bottom, in Pop order
+func (r *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walkChain(r.head, r.index, r.curBuffSize, func(_ int, v T) bool { return yield(v) })
+	}
+}
+
+// Return an iterator over the elements of the stack from bottom to top
+func (r *Stack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walkChainBackward(r.head, r.index, r.curBuffSize, yield)
+	}
+}
+
+// Return an iterator over the positions and elements of the stack from top to bottom
+func (r *Stack[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		walkChain(r.head, r.index, r.curBuffSize, yield)
+	}
+}
+
 // Return the number of elements in the Stack
 func (r *Stack[T]) Count() uint {
 	return r.curBuffSize
 }
+
+// Remove all elements from the stack. The head chunk is kept and the others are recycled, so a cleared
+// stack can be refilled without allocating. Complexity is O(n)
+func (r *Stack[T]) Clear() {
+	r.pool.putChain(r.head.next)
+	r.head.next = nil
+	clear(r.head.data)
+
+	r.curBuffSize = 0
+	r.index = r.chunkSize - 1
+}
+
+// Return a copy of the stack which shares no chunks with it. The elements themselves are copied by value.
+// Complexity is O(n)
+func (r *Stack[T]) Clone() *Stack[T] {
+	head, _ := cloneChain(r.head)
+	return &Stack[T]{
+		curBuffSize: r.curBuffSize,
+		index:       r.index,
+		chunkSize:   r.chunkSize,
+		pool:        r.pool.clone(),
+		head:        head,
+	}
+}
+
+// Release the spare chunks kept for reuse, see WithSpareChunks
+func (r *Stack[T]) Shrink() {
+	r.pool.shrink()
+}
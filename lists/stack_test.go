@@ -1,9 +1,243 @@
 package lists
 
 import (
+	"errors"
+	"slices"
 	"testing"
 )
 
+func TestStackIterators(t *testing.T) {
+	stacks := map[string]func() Lifo[int]{
+		"Stack":     func() Lifo[int] { return NewStack[int]() },
+		"SafeStack": func() Lifo[int] { return NewSafeStack[int]() },
+		"LockFree":  func() Lifo[int] { return NewLockFreeStack[int](WithElimination(4)) },
+	}
+
+	for name, newStack := range stacks {
+		for _, n := range []int{0, 1, 999, 1000, 1001, 2500} {
+			stack := newStack()
+			for i := 0; i < n; i++ {
+				stack.Push(i)
+			}
+
+			want := make([]int, 0, n)
+			for i := n - 1; i >= 0; i-- {
+				want = append(want, i)
+			}
+
+			if s := stack.ToSlice(); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) ToSlice() has %v elements, want %v", name, n, len(s), n)
+			}
+			if s := slices.Collect(stack.All()); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) All() has %v elements, want %v", name, n, len(s), n)
+			}
+
+			slices.Reverse(want)
+			if s := slices.Collect(stack.Backward()); !slices.Equal(s, want) {
+				t.Errorf("%v(%v) Backward() has %v elements, want %v", name, n, len(s), n)
+			}
+
+			for i, v := range stack.Indexed() {
+				if v != n-1-i {
+					t.Errorf("%v(%v) Indexed() = %v, %v, want %v, %v", name, n, i, v, i, n-1-i)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestStackBatchPop(t *testing.T) {
+	stacks := map[string]func() Lifo[int]{
+		"Stack":     func() Lifo[int] { return NewStack[int]() },
+		"SafeStack": func() Lifo[int] { return NewSafeStack[int]() },
+		"LockFree":  func() Lifo[int] { return NewLockFreeStack[int](WithElimination(4)) },
+	}
+
+	for name, newStack := range stacks {
+		stack := newStack()
+		for i := 0; i < 2500; i++ {
+			stack.Push(i)
+		}
+
+		s := stack.PopN(1200)
+		if len(s) != 1200 || s[0] != 2499 || s[1199] != 1300 {
+			t.Errorf("%v PopN() has %v elements, want %v from %v to %v", name, len(s), 1200, 2499, 1300)
+		}
+
+		dst := make([]int, 2000)
+		if n := stack.PopInto(dst); n != 1300 || dst[0] != 1299 || dst[1299] != 0 {
+			t.Errorf("%v PopInto() = %v, want %v from %v to %v", name, n, 1300, 1299, 0)
+		}
+
+		// the stack is still usable afterwards
+		for i := 0; i < 3; i++ {
+			stack.Push(i)
+		}
+		if s := slices.Collect(stack.Drain()); !slices.Equal(s, []int{2, 1, 0}) || !stack.IsEmpty() {
+			t.Errorf("%v Drain() = %v, want %v", name, s, []int{2, 1, 0})
+		}
+	}
+}
+
+func TestStackPushAll(t *testing.T) {
+	stacks := map[string]func() Lifo[int]{
+		"Stack":     func() Lifo[int] { return NewStack[int]() },
+		"SafeStack": func() Lifo[int] { return NewSafeStack[int]() },
+		"LockFree":  func() Lifo[int] { return NewLockFreeStack[int](WithElimination(4)) },
+	}
+
+	elements := make([]int, 0, 2500)
+	for i := 0; i < 2500; i++ {
+		elements = append(elements, i)
+	}
+
+	for name, newStack := range stacks {
+		stack := newStack()
+		stack.PushAll(elements[:1]...)
+		stack.PushAll(elements[1:1500]...)
+		stack.Push(1500)
+		stack.PushAll(elements[1501:]...)
+
+		for i := 2499; i >= 0; i-- {
+			if element, _ := stack.Pop(); element != i {
+				t.Errorf("%v Pop() = %v, want %v", name, element, i)
+				break
+			}
+		}
+		if !stack.IsEmpty() {
+			t.Errorf("%v IsEmpty() = %v, want %v", name, stack.IsEmpty(), true)
+		}
+	}
+}
+
+func TestStackChunkSize(t *testing.T) {
+	for _, size := range []int{1, 2, 7, 64} {
+		stacks := map[string]Lifo[int]{
+			"Stack":     NewStack[int](WithChunkSize(size)),
+			"SafeStack": NewSafeStack[int](WithChunkSize(size)),
+		}
+
+		for name, stack := range stacks {
+			for i := 0; i < 150; i++ {
+				stack.Push(i)
+			}
+			elements := make([]int, 0, 150)
+			for i := 150; i < 300; i++ {
+				elements = append(elements, i)
+			}
+			stack.PushAll(elements...)
+
+			if s := stack.PopN(100); len(s) != 100 || s[0] != 299 || s[99] != 200 {
+				t.Errorf("%v(%v) PopN() has %v elements, want %v", name, size, len(s), 100)
+			}
+			for i := 199; i >= 0; i-- {
+				if element, _ := stack.Pop(); element != i {
+					t.Errorf("%v(%v) Pop() = %v, want %v", name, size, element, i)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestStackClearClone(t *testing.T) {
+	stacks := map[string]Lifo[int]{
+		"Stack":     NewStack[int](WithChunkSize(4)),
+		"SafeStack": NewSafeStack[int](WithChunkSize(4)),
+	}
+
+	for name, stack := range stacks {
+		stack.PushAll(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+		var clone Lifo[int]
+		switch s := stack.(type) {
+		case *Stack[int]:
+			clone = s.Clone()
+		case *SafeStack[int]:
+			clone = s.Clone()
+		}
+
+		if s := clone.ToSlice(); !slices.Equal(s, stack.ToSlice()) {
+			t.Errorf("%v Clone().ToSlice() = %v, want %v", name, s, stack.ToSlice())
+		}
+
+		// the clone and the original no longer share storage
+		clone.Pop()
+		clone.Push(10)
+		if element, _ := stack.Peek(); element != 9 {
+			t.Errorf("%v Peek() after changing the clone = %v, want %v", name, element, 9)
+		}
+
+		stack.(interface{ Clear() }).Clear()
+		if !stack.IsEmpty() || stack.Count() != 0 {
+			t.Errorf("%v Count() after Clear() = %v, want %v", name, stack.Count(), 0)
+		}
+		if clone.Count() != 10 {
+			t.Errorf("%v clone Count() after Clear() = %v, want %v", name, clone.Count(), 10)
+		}
+
+		stack.PushAll(0, 1, 2, 3, 4, 5)
+		if s := stack.ToSlice(); !slices.Equal(s, []int{5, 4, 3, 2, 1, 0}) {
+			t.Errorf("%v ToSlice() after Clear() = %v, want %v", name, s, []int{5, 4, 3, 2, 1, 0})
+		}
+	}
+}
+
+func TestStackRandomAccess(t *testing.T) {
+	type randomAccessLifo interface {
+		Lifo[int]
+		At(i int) (int, error)
+		Set(i int, element int) error
+		PeekN(n int) []int
+		IndexFunc(f func(int) bool) int
+		ContainsFunc(f func(int) bool) bool
+	}
+
+	stacks := map[string]randomAccessLifo{
+		"Stack":     NewStack[int](WithChunkSize(4)),
+		"SafeStack": NewSafeStack[int](WithChunkSize(4)),
+	}
+
+	for name, stack := range stacks {
+		if _, err := stack.At(0); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%v At(0) on an empty stack error = %v, want %v", name, err, ErrEmpty)
+		}
+		if s := stack.PeekN(3); len(s) != 0 {
+			t.Errorf("%v PeekN(3) on an empty stack = %v, want %v", name, s, []int{})
+		}
+
+		stack.PushAll(9, 8, 7, 6, 5, 4, 3, 2, 1, 0)
+
+		// position 0 is the top of the stack
+		for i := 0; i < 10; i++ {
+			if element, err := stack.At(i); err != nil || element != i {
+				t.Errorf("%v At(%v) = %v, %v, want %v, %v", name, i, element, err, i, nil)
+			}
+		}
+		if _, err := stack.At(10); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%v At(10) error = %v, want %v", name, err, ErrOutOfRange)
+		}
+
+		if err := stack.Set(6, 60); err != nil {
+			t.Errorf("%v Set(6) error = %v, want %v", name, err, nil)
+		}
+		if s := stack.PeekN(7); !slices.Equal(s, []int{0, 1, 2, 3, 4, 5, 60}) {
+			t.Errorf("%v PeekN(7) = %v, want %v", name, s, []int{0, 1, 2, 3, 4, 5, 60})
+		}
+		if s := stack.PeekN(20); len(s) != 10 || stack.Count() != 10 {
+			t.Errorf("%v PeekN(20) = %v, want %v elements", name, s, 10)
+		}
+
+		if i := stack.IndexFunc(func(x int) bool { return x > 5 }); i != 6 {
+			t.Errorf("%v IndexFunc() = %v, want %v", name, i, 6)
+		}
+		if stack.ContainsFunc(func(x int) bool { return x == 6 }) {
+			t.Errorf("%v ContainsFunc() = %v, want %v", name, true, false)
+		}
+	}
+}
+
 func BenchmarkStackPush(b *testing.B) {
 	stack := NewStack[int]()
 
@@ -0,0 +1,266 @@
+package lists
+
+import "time"
+
+// Timeout is a handle to a timer scheduled on a TimingWheel. It is the node the wheel links into its slots,
+// so scheduling a timer allocates nothing besides the handle, and Reset schedules it again without allocating.
+type Timeout[T any] struct {
+	next, prev *Timeout[T]
+	wheel      *TimingWheel[T]
+	deadline   uint64 // the tick at which the timer expires
+
+	// The value returned by Advance once the timer expires
+	Value T
+}
+
+// Return the time at which the timer expires, rounded up to a whole tick of its wheel, or the zero time
+// if the timer is not pending
+func (t *Timeout[T]) Deadline() time.Time {
+	if t.wheel == nil {
+		return time.Time{}
+	}
+	return t.wheel.start.Add(time.Duration(t.deadline) * t.wheel.tick)
+}
+
+// Report whether the timer is scheduled, i.e. has neither expired nor been cancelled
+func (t *Timeout[T]) Pending() bool {
+	return t.wheel != nil
+}
+
+// The TimingWheel is a hierarchical timing wheel holding large numbers of timers, each carrying a value of
+// type T. Time is split into ticks, and every wheel level is a ring of slots where level 0 has one slot per
+// tick and every slot of level n+1 spans a whole revolution of level n. A timer is linked into the slot of the
+// lowest level its deadline fits in and moves down a level each time the level above reaches its slot, so
+// Schedule and Cancel take O(1) whatever the delay. Levels are added on demand for long delays.
+//
+// Timers expire with a resolution of one tick: a deadline is rounded up to the next tick, and a timer
+// never expires before its deadline.
+//
+// TimingWheel is not thread safe.
+type TimingWheel[T any] struct {
+	levels  [][]Timeout[T] // sentinels of the slots of every level
+	spans   []uint64       // number of ticks per slot of every level
+	tick    time.Duration
+	size    uint64
+	start   time.Time
+	current uint64 // the last tick processed by Advance
+	count   uint
+	clock   Clock
+}
+
+// The constructor for a new TimingWheel instance with timers carrying values of type T. tick is the
+// resolution of the wheel and size the number of slots per level. A tick of zero or less selects one
+// millisecond and a size below 2 selects 2. The wheel starts at the current time of its clock, the
+// system clock unless WithClock is used.
+//
+// Returns a pointer to a TimingWheel
+func NewTimingWheel[T any](tick time.Duration, size uint, opts ...ClockOption) *TimingWheel[T] {
+	config := newClockConfig(opts)
+	if tick <= 0 {
+		tick = time.Millisecond
+	}
+	if size < 2 {
+		size = 2
+	}
+
+	r := &TimingWheel[T]{
+		tick:  tick,
+		size:  uint64(size),
+		start: config.clock.Now(),
+		clock: config.clock,
+	}
+	r.addLevel()
+	return r
+}
+
+// A hidden method which adds a level above the existing ones
+func (r *TimingWheel[T]) addLevel() {
+	slots := make([]Timeout[T], r.size)
+	for i := range slots {
+		slots[i].next = &slots[i]
+		slots[i].prev = &slots[i]
+	}
+	span := uint64(1)
+	if n := len(r.spans); n > 0 {
+		span = r.spans[n-1] * r.size
+	}
+	r.levels = append(r.levels, slots)
+	r.spans = append(r.spans, span)
+}
+
+// A hidden method which returns the number of whole ticks between the start of the wheel and when
+func (r *TimingWheel[T]) ticks(when time.Time) uint64 {
+	d := when.Sub(r.start)
+	if d <= 0 {
+		return 0
+	}
+	return uint64(d / r.tick)
+}
+
+// A hidden method which links t into the slot its deadline falls in. The deadline must not be before the
+// current tick
+func (r *TimingWheel[T]) link(t *Timeout[T]) {
+	// find the lowest level whose ring covers the deadline, dividing first so the span can't overflow
+	delta := t.deadline - r.current
+	level := 0
+	for delta/r.size >= r.spans[level] {
+		level++
+		if level == len(r.levels) {
+			r.addLevel()
+		}
+	}
+
+	root := &r.levels[level][(t.deadline/r.spans[level])%r.size]
+	t.prev = root.prev
+	t.next = root
+	t.prev.next = t
+	root.prev = t
+}
+
+// A hidden method which unlinks t from its slot
+func (r *TimingWheel[T]) unlink(t *Timeout[T]) {
+	t.prev.next = t.next
+	t.next.prev = t.prev
+	t.next = nil // avoid memory leaks
+	t.prev = nil // avoid memory leaks
+}
+
+// A hidden method which schedules t to expire at when
+func (r *TimingWheel[T]) schedule(t *Timeout[T], when time.Time) {
+	d := when.Sub(r.start)
+	deadline := uint64(0)
+	if d > 0 {
+		deadline = uint64(d / r.tick)
+		if d%r.tick != 0 {
+			deadline++
+		}
+	}
+	// the slot of the current tick has been processed already
+	t.deadline = max(deadline, r.current+1)
+	t.wheel = r
+	r.link(t)
+	r.count++
+}
+
+// A hidden method which moves the timers of the given slot to lower levels
+func (r *TimingWheel[T]) cascade(root *Timeout[T]) {
+	t := root.next
+	root.next = root
+	root.prev = root
+	for t != root {
+		next := t.next
+		r.link(t)
+		t = next
+	}
+}
+
+// Return the number of pending timers
+func (r *TimingWheel[T]) Count() uint {
+	return r.count
+}
+
+// Checks if the wheel has no pending timers
+//
+// Return true if empty false otherwise
+func (r *TimingWheel[T]) IsEmpty() bool {
+	return r.count == 0
+}
+
+// Return the duration of a tick
+func (r *TimingWheel[T]) Tick() time.Duration {
+	return r.tick
+}
+
+// Return the time up to which the wheel has been advanced
+func (r *TimingWheel[T]) Now() time.Time {
+	return r.start.Add(time.Duration(r.current) * r.tick)
+}
+
+// Schedule a timer carrying value which expires once d has elapsed on the clock of the wheel and return its
+// handle. Complexity is O(1)
+func (r *TimingWheel[T]) Schedule(value T, d time.Duration) *Timeout[T] {
+	return r.ScheduleAt(value, r.clock.Now().Add(d))
+}
+
+// Schedule a timer carrying value which expires at when and return its handle. A time which has passed
+// already expires on the next tick. Complexity is O(1)
+func (r *TimingWheel[T]) ScheduleAt(value T, when time.Time) *Timeout[T] {
+	t := &Timeout[T]{Value: value}
+	r.schedule(t, when)
+	return t
+}
+
+// Schedule t again so it expires once d has elapsed on the clock of the wheel, whether it is pending,
+// expired or cancelled. A timer of another wheel is moved to this one. Complexity is O(1)
+func (r *TimingWheel[T]) Reset(t *Timeout[T], d time.Duration) {
+	if t.wheel != nil {
+		t.wheel.Cancel(t)
+	}
+	r.schedule(t, r.clock.Now().Add(d))
+}
+
+// Stop the timer t so it does not expire. Complexity is O(1)
+//
+// Returns false if t is not pending on this wheel
+func (r *TimingWheel[T]) Cancel(t *Timeout[T]) bool {
+	if t.wheel != r {
+		return false
+	}
+	r.unlink(t)
+	t.wheel = nil
+	r.count--
+	return true
+}
+
+// Move the wheel forward to now and return the values of the timers which expired, in the order of their
+// deadline tick. Complexity is O(k + m) for k elapsed ticks and m expired or cascaded timers, and a wheel
+// without pending timers moves in O(1)
+func (r *TimingWheel[T]) Advance(now time.Time) []T {
+	var expired []T
+	target := r.ticks(now)
+
+	for r.current < target {
+		if r.count == 0 {
+			r.current = target
+			break
+		}
+		r.current++
+
+		// move timers down from the levels whose slot starts at this tick, top first
+		top := 0
+		for top+1 < len(r.levels) && r.current%r.spans[top+1] == 0 {
+			top++
+		}
+		for level := top; level >= 1; level-- {
+			r.cascade(&r.levels[level][(r.current/r.spans[level])%r.size])
+		}
+
+		root := &r.levels[0][r.current%r.size]
+		for t := root.next; t != root; t = root.next {
+			r.unlink(t)
+			t.wheel = nil
+			r.count--
+			expired = append(expired, t.Value)
+		}
+	}
+	return expired
+}
+
+// Move the wheel forward to the current time of its clock and return the values of the timers which expired
+func (r *TimingWheel[T]) Expire() []T {
+	return r.Advance(r.clock.Now())
+}
+
+// Stop all pending timers. Complexity is O(n) plus the number of slots
+func (r *TimingWheel[T]) Clear() {
+	for _, slots := range r.levels {
+		for i := range slots {
+			root := &slots[i]
+			for t := root.next; t != root; t = root.next {
+				r.unlink(t)
+				t.wheel = nil
+			}
+		}
+	}
+	r.count = 0
+}
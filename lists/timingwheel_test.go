@@ -0,0 +1,152 @@
+package lists
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimingWheel(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	wheel := NewTimingWheel[string](time.Millisecond, 8, WithClock(clock))
+
+	wheel.Schedule("b", 5*time.Millisecond)
+	wheel.Schedule("a", 1500*time.Microsecond) // rounded up to 2ms
+	late := wheel.Schedule("c", time.Hour)     // needs several levels of 8 slots
+	cancelled := wheel.Schedule("x", 3*time.Millisecond)
+
+	if wheel.Count() != 4 {
+		t.Errorf("Count() = %v, want %v", wheel.Count(), 4)
+	}
+	if !wheel.Cancel(cancelled) || wheel.Cancel(cancelled) || cancelled.Pending() {
+		t.Errorf("Cancel() of a pending timer did not stop it exactly once")
+	}
+	if deadline := late.Deadline(); !deadline.Equal(time.Unix(3600, 0)) {
+		t.Errorf("Deadline() = %v, want %v", deadline, time.Unix(3600, 0))
+	}
+
+	clock.Advance(time.Millisecond)
+	if s := wheel.Expire(); len(s) != 0 {
+		t.Errorf("Expire() = %v, want none", s)
+	}
+	clock.Advance(time.Millisecond)
+	if s := wheel.Expire(); !slices.Equal(s, []string{"a"}) {
+		t.Errorf("Expire() = %v, want %v", s, []string{"a"})
+	}
+	clock.Advance(time.Hour - 3*time.Millisecond)
+	if s := wheel.Expire(); !slices.Equal(s, []string{"b"}) {
+		t.Errorf("Expire() = %v, want %v", s, []string{"b"})
+	}
+	clock.Advance(time.Millisecond)
+	if s := wheel.Expire(); !slices.Equal(s, []string{"c"}) || late.Pending() {
+		t.Errorf("Expire() = %v, want %v", s, []string{"c"})
+	}
+
+	// an expired handle can be scheduled again, a time in the past expires on the next tick
+	wheel.Reset(late, time.Second)
+	wheel.ScheduleAt("past", time.Unix(0, 0))
+	if s := wheel.Advance(wheel.Now().Add(time.Millisecond)); !slices.Equal(s, []string{"past"}) {
+		t.Errorf("Advance() = %v, want %v", s, []string{"past"})
+	}
+	wheel.Reset(late, 2*time.Second)
+	if s := wheel.Advance(clock.Now().Add(time.Second)); len(s) != 0 {
+		t.Errorf("Advance() = %v, want none", s)
+	}
+	wheel.Clear()
+	if !wheel.IsEmpty() || late.Pending() {
+		t.Errorf("IsEmpty() after Clear() = %v, want %v", wheel.IsEmpty(), true)
+	}
+}
+
+func TestTimingWheelRandomized(t *testing.T) {
+	for _, size := range []uint{2, 3, 16, 64} {
+		wheel := NewTimingWheel[int](time.Millisecond, size, WithClock(NewManualClock(time.Unix(0, 0))))
+		rng := rand.New(rand.NewSource(int64(size)))
+		now := time.Unix(0, 0)
+
+		// the deadline tick of every pending timer
+		ref := make(map[int]uint64)
+		handles := make(map[int]*Timeout[int])
+		next := 0
+
+		for step := 0; step < 20000; step++ {
+			switch rng.Intn(4) {
+			case 0, 1:
+				// spread the delays over several orders of magnitude to use many levels
+				d := time.Duration(rng.Int63n(int64(time.Millisecond) << rng.Intn(20)))
+				handles[next] = wheel.ScheduleAt(next, now.Add(d))
+				ref[next] = handles[next].deadline
+				if handles[next].Deadline().Before(now.Add(d)) {
+					t.Fatalf("size=%v Deadline() = %v is before %v", size, handles[next].Deadline(), now.Add(d))
+				}
+				next++
+			case 2:
+				for id := range ref {
+					if !wheel.Cancel(handles[id]) {
+						t.Fatalf("size=%v Cancel() of a pending timer = %v", size, false)
+					}
+					delete(ref, id)
+					break
+				}
+			case 3:
+				now = now.Add(time.Duration(rng.Int63n(int64(50 * time.Millisecond))))
+				tick := wheel.ticks(now)
+				expired := wheel.Advance(now)
+				want := make([]int, 0)
+				for id, deadline := range ref {
+					if deadline <= tick {
+						want = append(want, id)
+						delete(ref, id)
+					}
+				}
+				if !slices.IsSortedFunc(expired, func(a, b int) int { return cmp.Compare(handles[a].deadline, handles[b].deadline) }) {
+					t.Fatalf("size=%v Advance() returned timers out of deadline order", size)
+				}
+				slices.Sort(expired)
+				slices.Sort(want)
+				if !slices.Equal(expired, want) {
+					t.Fatalf("size=%v Advance() = %v, want %v", size, expired, want)
+				}
+			}
+			if int(wheel.Count()) != len(ref) {
+				t.Fatalf("size=%v Count() = %v, want %v", size, wheel.Count(), len(ref))
+			}
+		}
+	}
+}
+
+func BenchmarkTimingWheelScheduleCancel(b *testing.B) {
+	wheel := NewTimingWheel[int](time.Millisecond, 256)
+	delays := make([]time.Duration, 1024)
+	for i := range delays {
+		delays[i] = time.Duration(rand.Int63n(int64(time.Hour)))
+	}
+	pending := make([]*Timeout[int], 0, 100000)
+	for i := 0; i < cap(pending); i++ {
+		pending = append(pending, wheel.Schedule(i, delays[i%len(delays)]))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wheel.Reset(pending[i%len(pending)], delays[i%len(delays)])
+	}
+}
+
+func BenchmarkDelayQueueScheduleDequeue(b *testing.B) {
+	queue := NewDelayQueue[int]()
+	delays := make([]time.Duration, 1024)
+	for i := range delays {
+		delays[i] = time.Duration(rand.Int63n(int64(time.Hour)))
+	}
+	for i := 0; i < 100000; i++ {
+		queue.EnqueueAfter(i, delays[i%len(delays)])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.EnqueueAfter(i, delays[i%len(delays)])
+		queue.queue.Dequeue()
+	}
+}
@@ -0,0 +1,178 @@
+package lists
+
+import (
+	"sync/atomic"
+)
+
+// StealResult tells how a Steal from a WorkStealingDeque went
+type StealResult int
+
+const (
+	// The deque was empty
+	StealEmpty StealResult = iota
+	// Another thief or the owner took the element first. The deque may still hold elements, so it is
+	// worth trying again
+	StealAbort
+	// The element was stolen
+	StealSuccess
+)
+
+// Struct holding the circular array of a WorkStealingDeque. Position i lives in slot i&mask. Elements are
+// boxed, so a thief reading a slot while the owner reuses it sees either the old or the new box but never
+// a torn element
+type wsArray[T any] struct {
+	slots []atomic.Pointer[T]
+	mask  int64
+}
+
+// A hidden function which returns an array of size slots, size being a power of two
+func newWSArray[T any](size int64) *wsArray[T] {
+	return &wsArray[T]{
+		slots: make([]atomic.Pointer[T], size),
+		mask:  size - 1,
+	}
+}
+
+// A hidden method which returns the slot of position i
+func (a *wsArray[T]) slot(i int64) *atomic.Pointer[T] {
+	return &a.slots[i&a.mask]
+}
+
+// A hidden method which returns an array of twice the size holding the positions from top up to bottom
+func (a *wsArray[T]) grow(top, bottom int64) *wsArray[T] {
+	b := newWSArray[T](2 * int64(len(a.slots)))
+	for i := top; i < bottom; i++ {
+		b.slot(i).Store(a.slot(i).Load())
+	}
+	return b
+}
+
+// The WorkStealingDeque is the deque of Chase and Lev for work-stealing schedulers. Each worker owns a
+// deque: the owner pushes and pops tasks at the bottom, in Lifo order, while other workers, the thieves,
+// steal the oldest tasks from the top. The owner only meets the thieves with a compare-and-swap when a
+// single element is left, and thieves only meet each other on the top index, so nobody waits for a lock.
+// The circular array doubles when it runs full and never shrinks. Old arrays are left to the garbage
+// collector, so a thief still reading one is never disturbed.
+//
+// Only one goroutine at a time may act as the owner, calling PushBottom and PopBottom. Steal, Count,
+// IsEmpty and Capacity may be called from anywhere. It is up to the developer to ensure thread safety
+// of the internals of the data.
+type WorkStealingDeque[T any] struct {
+	_      cacheLinePad
+	top    atomic.Int64 // position of the oldest element, only ever increased by a compare-and-swap
+	_      cacheLinePad
+	bottom atomic.Int64 // position of the next free slot, only written by the owner
+	array  atomic.Pointer[wsArray[T]]
+	_      cacheLinePad
+}
+
+// The constructor for a new WorkStealingDeque instance with elements of type T whose array starts out
+// holding size elements. The size is rounded up to a power of two, and a size of 0 is treated as 1.
+//
+// Returns a pointer to a WorkStealingDeque
+func NewWorkStealingDeque[T any](size uint) *WorkStealingDeque[T] {
+	n := int64(1)
+	for n < int64(size) {
+		n <<= 1
+	}
+
+	r := &WorkStealingDeque[T]{}
+	r.array.Store(newWSArray[T](n))
+	return r
+}
+
+// Return the number of elements the current array holds before it grows
+func (r *WorkStealingDeque[T]) Capacity() int {
+	return len(r.array.Load().slots)
+}
+
+// Return the number of elements in the deque. While other goroutines are active the count may be
+// outdated as soon as it is returned
+func (r *WorkStealingDeque[T]) Count() uint {
+	top := r.top.Load()
+	bottom := r.bottom.Load()
+	return uint(max(bottom-top, 0))
+}
+
+// Checks if the deque is empty
+//
+// Return true if empty false otherwise
+func (r *WorkStealingDeque[T]) IsEmpty() bool {
+	return r.Count() == 0
+}
+
+// Add an element of type T at the bottom of the deque, doubling the array if it is full. Only the owner
+// may call PushBottom. Complexity is O(1), amortized when the array grows
+func (r *WorkStealingDeque[T]) PushBottom(element T) {
+	bottom := r.bottom.Load()
+	top := r.top.Load()
+	array := r.array.Load()
+	if bottom-top >= int64(len(array.slots)) {
+		array = array.grow(top, bottom)
+		r.array.Store(array)
+	}
+	array.slot(bottom).Store(&element)
+	// publishing the new bottom hands the element over to the thieves
+	r.bottom.Store(bottom + 1)
+}
+
+// Remove and return the element at the bottom of the deque, the one pushed last. Only the owner may
+// call PopBottom. Complexity is O(1)
+//
+// Returns an error if the deque is empty, or thieves took the last element first
+func (r *WorkStealingDeque[T]) PopBottom() (T, error) {
+	var result T
+
+	// claim the bottom element before looking at top, so a thief going for the same element sees the claim
+	bottom := r.bottom.Load() - 1
+	array := r.array.Load()
+	r.bottom.Store(bottom)
+	top := r.top.Load()
+
+	if top > bottom {
+		r.bottom.Store(bottom + 1)
+		return result, newContainerError("WorkStealingDeque", "PopBottom", ErrEmpty)
+	}
+
+	slot := array.slot(bottom)
+	box := slot.Load()
+	if top == bottom {
+		// the last element, race the thieves for it
+		won := r.top.CompareAndSwap(top, top+1)
+		r.bottom.Store(bottom + 1)
+		if !won {
+			return result, newContainerError("WorkStealingDeque", "PopBottom", ErrEmpty)
+		}
+	}
+	slot.CompareAndSwap(box, nil)
+	return *box, nil
+}
+
+// Remove and return the element at the top of the deque, the oldest one. Steal may be called from any
+// goroutine. Complexity is O(1)
+//
+// Returns StealSuccess with the element, StealEmpty if the deque is empty, or StealAbort if another
+// thief or the owner took the element first
+func (r *WorkStealingDeque[T]) Steal() (T, StealResult) {
+	var result T
+
+	top := r.top.Load()
+	bottom := r.bottom.Load()
+	if top >= bottom {
+		return result, StealEmpty
+	}
+
+	array := r.array.Load()
+	box := array.slot(top).Load()
+	if box == nil || !r.top.CompareAndSwap(top, top+1) {
+		// the element at top was taken since reading top
+		return result, StealAbort
+	}
+
+	// release the slot unless the owner already reused it. The array may have grown meanwhile
+	array.slot(top).CompareAndSwap(box, nil)
+	if current := r.array.Load(); current != array {
+		current.slot(top).CompareAndSwap(box, nil)
+	}
+	return *box, StealSuccess
+}
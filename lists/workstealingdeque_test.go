@@ -0,0 +1,156 @@
+package lists
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestWorkStealingDeque(t *testing.T) {
+	deque := NewWorkStealingDeque[int](2)
+
+	if _, err := deque.PopBottom(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("PopBottom() on an empty deque error = %v, want %v", err, ErrEmpty)
+	}
+	if _, result := deque.Steal(); result != StealEmpty {
+		t.Errorf("Steal() on an empty deque = %v, want %v", result, StealEmpty)
+	}
+
+	// the array grows while elements were stolen from the front
+	for i := 0; i < 3; i++ {
+		deque.PushBottom(i)
+	}
+	if element, result := deque.Steal(); element != 0 || result != StealSuccess {
+		t.Errorf("Steal() = %v, %v, want %v, %v", element, result, 0, StealSuccess)
+	}
+	for i := 3; i < 10; i++ {
+		deque.PushBottom(i)
+	}
+	if deque.Capacity() != 16 || deque.Count() != 9 {
+		t.Errorf("Capacity(), Count() = %v, %v, want %v, %v", deque.Capacity(), deque.Count(), 16, 9)
+	}
+
+	// the owner takes the newest elements, thieves the oldest
+	for _, want := range []int{9, 8, 7} {
+		if element, err := deque.PopBottom(); element != want || err != nil {
+			t.Errorf("PopBottom() = %v, %v, want %v, %v", element, err, want, nil)
+		}
+	}
+	for _, want := range []int{1, 2, 3, 4, 5} {
+		if element, result := deque.Steal(); element != want || result != StealSuccess {
+			t.Errorf("Steal() = %v, %v, want %v, %v", element, result, want, StealSuccess)
+		}
+	}
+	if element, err := deque.PopBottom(); element != 6 || err != nil {
+		t.Errorf("PopBottom() = %v, %v, want %v, %v", element, err, 6, nil)
+	}
+	if !deque.IsEmpty() {
+		t.Errorf("IsEmpty() = %v, want %v", deque.IsEmpty(), true)
+	}
+	if _, err := deque.PopBottom(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("PopBottom() on an empty deque error = %v, want %v", err, ErrEmpty)
+	}
+
+	if NewWorkStealingDeque[int](0).Capacity() != 1 {
+		t.Errorf("Capacity() of a zero size deque = %v, want %v", NewWorkStealingDeque[int](0).Capacity(), 1)
+	}
+}
+
+// The owner pushes and pops while thieves steal. Every element must be taken exactly once, every PopBottom
+// must return the newest element the owner hasn't popped itself, since thieves can't take it before the
+// older ones, and every thief must see elements in the order they were pushed
+func TestWorkStealingDequeConcurrent(t *testing.T) {
+	const thieves, n = 4, 100000
+	deque := NewWorkStealingDeque[int](4)
+
+	stolen := make([][]int, thieves)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for th := 0; th < thieves; th++ {
+		wg.Add(1)
+		go func(th int) {
+			defer wg.Done()
+			for {
+				element, result := deque.Steal()
+				switch result {
+				case StealSuccess:
+					stolen[th] = append(stolen[th], element)
+					continue
+				case StealEmpty:
+					select {
+					case <-done:
+						return
+					default:
+					}
+				}
+				runtime.Gosched()
+			}
+		}(th)
+	}
+
+	var popped, pending []int
+	pop := func() {
+		element, err := deque.PopBottom()
+		if err != nil {
+			// thieves took everything that was left
+			pending = pending[:0]
+			return
+		}
+		if want := pending[len(pending)-1]; element != want {
+			t.Fatalf("PopBottom() = %v, want %v", element, want)
+		}
+		pending = pending[:len(pending)-1]
+		popped = append(popped, element)
+	}
+	for i := 0; i < n; i++ {
+		deque.PushBottom(i)
+		pending = append(pending, i)
+		if i%3 == 2 {
+			pop()
+			pop()
+		}
+		if i%1000 == 0 {
+			runtime.Gosched()
+		}
+	}
+	for !deque.IsEmpty() {
+		pop()
+	}
+	close(done)
+	wg.Wait()
+
+	count := make([]int, n)
+	for _, element := range popped {
+		count[element]++
+	}
+	for th := range stolen {
+		for i, element := range stolen[th] {
+			count[element]++
+			if i > 0 && element <= stolen[th][i-1] {
+				t.Fatalf("thief %v stole %v after %v", th, element, stolen[th][i-1])
+			}
+		}
+	}
+	for element, k := range count {
+		if k != 1 {
+			t.Fatalf("element %v taken %v times, want %v", element, k, 1)
+		}
+	}
+}
+
+func BenchmarkWorkStealingDequeOwner(b *testing.B) {
+	deque := NewWorkStealingDeque[int](64)
+	for i := 0; i < b.N; i++ {
+		deque.PushBottom(i)
+		deque.PopBottom()
+	}
+}
+
+func BenchmarkWorkStealingDequeSteal(b *testing.B) {
+	deque := NewWorkStealingDeque[int](64)
+	for i := 0; i < b.N; i++ {
+		deque.PushBottom(i)
+		deque.Steal()
+	}
+}